@@ -0,0 +1,317 @@
+package loncha
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/cespare/xxhash"
+)
+
+// HashKeyFunc ... return bytes of element hashed by xxhash in CountDistinct()/UniqApprox()/ContainsApprox()
+type HashKeyFunc[T any] func(t *T) []byte
+
+// ApproxOpt ... functional option for CountDistinct()/UniqApprox()/ContainsApprox()
+type ApproxOpt struct {
+	precision uint8
+	fpRate    float64
+	capacity  int
+}
+
+func (aopt *ApproxOpt) hllPrecision(p uint8) (prev uint8) {
+	prev = aopt.precision
+	aopt.precision = p
+	return prev
+}
+
+func (aopt *ApproxOpt) falsePositive(rate float64) (prev float64) {
+	prev = aopt.fpRate
+	aopt.fpRate = rate
+	return prev
+}
+
+func (aopt *ApproxOpt) bloomCapacity(n int) (prev int) {
+	prev = aopt.capacity
+	aopt.capacity = n
+	return prev
+}
+
+type precisionSetter[T any] interface {
+	hllPrecision(uint8) uint8
+	*T
+}
+
+type falsePositiveSetter[T any] interface {
+	falsePositive(float64) float64
+	*T
+}
+
+type capacitySetter[T any] interface {
+	bloomCapacity(int) int
+	*T
+}
+
+// Precision ... set precision of HyperLogLog. default is 14 (error is about 0.8%)
+func Precision[T any, PT precisionSetter[T]](p uint8) Opt[T] {
+	return func(param *opParam[T]) Opt[T] {
+		prev := PT(&param.Param).hllPrecision(p)
+		return Precision[T, PT](prev)
+	}
+}
+
+// FalsePositive ... set false positive rate of BloomFilter. default is 0.01
+func FalsePositive[T any, PT falsePositiveSetter[T]](rate float64) Opt[T] {
+	return func(param *opParam[T]) Opt[T] {
+		prev := PT(&param.Param).falsePositive(rate)
+		return FalsePositive[T, PT](prev)
+	}
+}
+
+// Capacity ... set expected number of elements of BloomFilter. default is length of slice.
+// set expected total of all shards to merge per-shard filters with same size.
+func Capacity[T any, PT capacitySetter[T]](n int) Opt[T] {
+	return func(param *opParam[T]) Opt[T] {
+		prev := PT(&param.Param).bloomCapacity(n)
+		return Capacity[T, PT](prev)
+	}
+}
+
+func (aopt *ApproxOpt) newBloomFilter(n int) *BloomFilter {
+	if aopt.capacity > 0 {
+		n = aopt.capacity
+	}
+	return NewBloomFilter(n, aopt.fpRate)
+}
+
+const (
+	hllMinPrecision     uint8   = 4
+	hllMaxPrecision     uint8   = 18
+	hllDefaultPrecision uint8   = 14
+	bloomDefaultFpRate  float64 = 0.01
+)
+
+// HyperLogLog ... sketch for estimating the number of distinct elements.
+type HyperLogLog struct {
+	precision uint8
+	registers []uint8
+}
+
+// NewHyperLogLog ... create HyperLogLog with 2^precision registers. precision is clamped in 4..18.
+func NewHyperLogLog(precision uint8) *HyperLogLog {
+
+	precision = min(max(precision, hllMinPrecision), hllMaxPrecision)
+	return &HyperLogLog{
+		precision: precision,
+		registers: make([]uint8, 1<<precision),
+	}
+}
+
+// Add ... add data to sketch.
+func (h *HyperLogLog) Add(data []byte) {
+	h.AddHash(xxhash.Sum64(data))
+}
+
+// AddHash ... add 64bit hash value to sketch.
+func (h *HyperLogLog) AddHash(x uint64) {
+
+	idx := x >> (64 - h.precision)
+	w := x<<h.precision | 1<<(h.precision-1)
+	rho := uint8(bits.LeadingZeros64(w) + 1)
+	if rho > h.registers[idx] {
+		h.registers[idx] = rho
+	}
+}
+
+// Count ... return estimated number of distinct elements.
+func (h *HyperLogLog) Count() uint64 {
+
+	m := float64(len(h.registers))
+	var alpha float64
+	switch len(h.registers) {
+	case 16:
+		alpha = 0.673
+	case 32:
+		alpha = 0.697
+	case 64:
+		alpha = 0.709
+	default:
+		alpha = 0.7213 / (1 + 1.079/m)
+	}
+
+	sum, zeros := 0.0, 0
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+	estimate := alpha * m * m / sum
+
+	// small range correction by linear counting
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(estimate + 0.5)
+}
+
+// Merge ... merge other sketch. h will estimate union of both.
+// return ERR_SKETCH_MISMATCH if precision is different.
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+
+	if h.precision != other.precision {
+		return ERR_SKETCH_MISMATCH
+	}
+	for i, r := range other.registers {
+		if r > h.registers[i] {
+			h.registers[i] = r
+		}
+	}
+	return nil
+}
+
+// BloomFilter ... probabilistic set. Contains() may return true for absent data with false positive rate,
+// but never return false for added data.
+type BloomFilter struct {
+	bits []uint64
+	m    uint64
+	k    uint64
+}
+
+// NewBloomFilter ... create BloomFilter sized for n elements with false positive rate fpRate.
+func NewBloomFilter(n int, fpRate float64) *BloomFilter {
+
+	n = max(n, 1)
+	if fpRate <= 0 || fpRate >= 1 {
+		fpRate = bloomDefaultFpRate
+	}
+	m := uint64(math.Ceil(-float64(n) * math.Log(fpRate) / (math.Ln2 * math.Ln2)))
+	m = max(m, 64)
+	k := uint64(math.Round(float64(m) / float64(n) * math.Ln2))
+	k = max(k, 1)
+
+	return &BloomFilter{
+		bits: make([]uint64, (m+63)/64),
+		m:    m,
+		k:    k,
+	}
+}
+
+// positions ... call fn with k bit positions of hash x by double hashing.
+func (b *BloomFilter) positions(x uint64, fn func(pos uint64) bool) {
+
+	h1, h2 := x&math.MaxUint32, x>>32|1
+	for i := uint64(0); i < b.k; i++ {
+		if !fn((h1 + i*h2) % b.m) {
+			return
+		}
+	}
+}
+
+// Add ... add data to filter.
+func (b *BloomFilter) Add(data []byte) {
+	b.positions(xxhash.Sum64(data), func(pos uint64) bool {
+		b.bits[pos/64] |= 1 << (pos % 64)
+		return true
+	})
+}
+
+// Contains ... return true if data may be added.
+func (b *BloomFilter) Contains(data []byte) (found bool) {
+	found = true
+	b.positions(xxhash.Sum64(data), func(pos uint64) bool {
+		found = b.bits[pos/64]&(1<<(pos%64)) != 0
+		return found
+	})
+	return
+}
+
+// AddIfAbsent ... add data and return true if data is not contained before.
+func (b *BloomFilter) AddIfAbsent(data []byte) (added bool) {
+	b.positions(xxhash.Sum64(data), func(pos uint64) bool {
+		if b.bits[pos/64]&(1<<(pos%64)) == 0 {
+			b.bits[pos/64] |= 1 << (pos % 64)
+			added = true
+		}
+		return true
+	})
+	return
+}
+
+// Merge ... merge other filter. b will contain union of both.
+// return ERR_SKETCH_MISMATCH if size is different, so create filters of shards with same Capacity().
+// false positive rate of merged filter is the configured one only while total elements are within the size.
+// if filters are sized for each shard, merged filter holds more elements and false positive rate increases.
+func (b *BloomFilter) Merge(other *BloomFilter) error {
+
+	if b.m != other.m || b.k != other.k {
+		return ERR_SKETCH_MISMATCH
+	}
+	for i := range other.bits {
+		b.bits[i] |= other.bits[i]
+	}
+	return nil
+}
+
+// NewHyperLogLogOf ... create HyperLogLog with elements of s.
+func NewHyperLogLogOf[T any](s []T, keyFn HashKeyFunc[T], opts ...Opt[ApproxOpt]) *HyperLogLog {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	precision := param.Param.precision
+	if precision == 0 {
+		precision = hllDefaultPrecision
+	}
+	h := NewHyperLogLog(precision)
+	for i := range s {
+		h.Add(keyFn(&s[i]))
+	}
+	return h
+}
+
+// CountDistinct ... return estimated number of distinct elements by HyperLogLog.
+// use NewHyperLogLogOf() and Merge() to combine per-shard sketches.
+func CountDistinct[T any](s []T, keyFn HashKeyFunc[T], opts ...Opt[ApproxOpt]) uint64 {
+	return NewHyperLogLogOf(s, keyFn, opts...).Count()
+}
+
+// NewBloomFilterOf ... create BloomFilter with elements of s. size is for len(s) elements.
+// use Capacity[ApproxOpt]() with expected total to merge per-shard filters.
+func NewBloomFilterOf[T any](s []T, keyFn HashKeyFunc[T], opts ...Opt[ApproxOpt]) *BloomFilter {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	b := param.Param.newBloomFilter(len(s))
+	for i := range s {
+		b.Add(keyFn(&s[i]))
+	}
+	return b
+}
+
+// UniqApprox ... deduplicate s in place with BloomFilter and return truncated s. first element is kept.
+// unique element may be removed with false positive rate, but duplicated element is never kept.
+func UniqApprox[T any](s []T, keyFn HashKeyFunc[T], opts ...Opt[ApproxOpt]) []T {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	b := param.Param.newBloomFilter(len(s))
+	n := 0
+	for i := range s {
+		if b.AddIfAbsent(keyFn(&s[i])) {
+			s[n] = s[i]
+			n++
+		}
+	}
+	return truncate(s, n)
+}
+
+// ContainsApprox ... generate condition function which returns true if element may be in s.
+// this can be used with Filter() as approximate Intersect().
+func ContainsApprox[T any](s []T, keyFn HashKeyFunc[T], opts ...Opt[ApproxOpt]) CondFunc2[T] {
+
+	b := NewBloomFilterOf(s, keyFn, opts...)
+	return func(t *T) bool {
+		return b.Contains(keyFn(t))
+	}
+}
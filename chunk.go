@@ -0,0 +1,55 @@
+package loncha
+
+// Chunk ... split slice into sub slices of n elements. last chunk may be shorter.
+// sub slices share the memory of s without copying. return nil if n is not positive.
+func Chunk[T any](s []T, n int) (chunks [][]T) {
+
+	if n <= 0 {
+		return nil
+	}
+	chunks = make([][]T, 0, (len(s)+n-1)/n)
+	for i := 0; i < len(s); i += n {
+		end := min(i+n, len(s))
+		chunks = append(chunks, s[i:end:end])
+	}
+	return
+}
+
+// Window ... return sub slices of size elements moving by step. partial window at the end is not returned.
+// sub slices share the memory of s without copying. return nil if size or step is not positive.
+func Window[T any](s []T, size, step int) (windows [][]T) {
+
+	if size <= 0 || step <= 0 {
+		return nil
+	}
+	windows = make([][]T, 0, max((len(s)-size)/step+1, 0))
+	for i := 0; i+size <= len(s); i += step {
+		windows = append(windows, s[i:i+size:i+size])
+	}
+	return
+}
+
+// SlidingInject ... return Inject() result of each window of size elements moving by one.
+// if removeFn is given, aggregate is updated by injectFn with new element and removeFn with old element,
+// so each step is O(1). if removeFn is nil, each window is injected from start.
+func SlidingInject[T, V any](s []T, size int, injectFn InjectFn[T, V], removeFn InjectFn[T, V], opts ...OptCurry[V]) (results []V) {
+
+	if removeFn == nil {
+		return Convertable(func(w []T) (V, bool) {
+			return Inject(w, injectFn, opts...), false
+		})(Window(s, size, 1))
+	}
+
+	if size <= 0 || len(s) < size {
+		return []V{}
+	}
+	results = make([]V, 0, len(s)-size+1)
+
+	v := Inject(s[:size], injectFn, opts...)
+	results = append(results, v)
+	for i := size; i < len(s); i++ {
+		v = removeFn(injectFn(v, s[i]), s[i-size])
+		results = append(results, v)
+	}
+	return
+}
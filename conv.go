@@ -2,7 +2,25 @@ package loncha
 
 type ConvFunc[T any, S any] func(T) (S, bool)
 
-func Conv[S, D any](srcs []S, convfn ConvFunc[S, D]) (dsts []D) {
+// Conv ... convert srcs by convfn. element which convfn returns true as removed is not converted.
+// with WithContext[CtxOpt](), stop when context is done and return the converted elements before it.
+func Conv[S, D any](srcs []S, convfn ConvFunc[S, D], opts ...Opt[CtxOpt]) (dsts []D) {
 
-	return Convertable(convfn)(srcs)
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	if param.Param.ctx == nil {
+		return Convertable(convfn)(srcs)
+	}
+
+	dsts = []D{}
+	for i, src := range srcs {
+		if param.Param.canceled(i) != nil {
+			return
+		}
+		if d, removed := convfn(src); !removed {
+			dsts = append(dsts, d)
+		}
+	}
+	return
 }
@@ -0,0 +1,165 @@
+package loncha
+
+import (
+	"context"
+	"fmt"
+)
+
+// ctxCheckEvery ... context is checked every ctxCheckEvery elements
+const ctxCheckEvery int = 1024
+
+// PartialError ... error of canceled operation. result is processed partially.
+// Processed is the number of elements processed before canceled.
+type PartialError struct {
+	Err       error
+	Processed int
+}
+
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("canceled after %d elements: %s", e.Processed, e.Err.Error())
+}
+
+// Unwrap ... return ctx.Err()
+func (e *PartialError) Unwrap() error {
+	return e.Err
+}
+
+type ctxOpt struct {
+	ctx  context.Context
+	perr *error
+}
+
+func (c *ctxOpt) setContext(v ctxOpt) (prev ctxOpt) {
+	prev = *c
+	*c = v
+	return prev
+}
+
+// canceled ... check context every ctxCheckEvery elements. i is the number of processed elements.
+// if canceled, return *PartialError and store it to perr.
+func (c *ctxOpt) canceled(i int) error {
+
+	if c.ctx == nil || i%ctxCheckEvery != 0 {
+		return nil
+	}
+	err := c.ctx.Err()
+	if err == nil {
+		return nil
+	}
+	perr := &PartialError{Err: err, Processed: i}
+	if c.perr != nil {
+		*c.perr = perr
+	}
+	return perr
+}
+
+// CtxOpt ... functional option for Conv()/UniqWithSort() to set context
+type CtxOpt struct {
+	ctxOpt
+}
+
+type contextSetter[T any] interface {
+	setContext(ctxOpt) ctxOpt
+	*T
+}
+
+// WithContext ... set context to cancel long running operation.
+// if ctx is done, operation stops and *PartialError is stored to perr.
+// Filter() and UniqWithSort() also return it as error, so perr can be nil for them.
+// Conv()/Intersect()/Sub() have no error to return. if perr is nil, truncated result cannot be distinguished,
+// so use ConvContext()/IntersectContext()/SubContext() instead.
+func WithContext[T any, PT contextSetter[T]](ctx context.Context, perr *error) Opt[T] {
+	return withCtxOpt[T, PT](ctxOpt{ctx: ctx, perr: perr})
+}
+
+func withCtxOpt[T any, PT contextSetter[T]](v ctxOpt) Opt[T] {
+	return func(p *opParam[T]) Opt[T] {
+		prev := PT(&p.Param).setContext(v)
+		return withCtxOpt[T, PT](prev)
+	}
+}
+
+// ContextCurry ... OptCurry variant of WithContext for Inject(). see InjectContext() to get error as result.
+func ContextCurry[T any](ctx context.Context, perr *error) OptCurry[T] {
+	return ctxCurry[T](ctxOpt{ctx: ctx, perr: perr})
+}
+
+func ctxCurry[T any](v ctxOpt) OptCurry[T] {
+	return func(p *curryParam[T]) OptCurry[T] {
+		prev := p.setContext(v)
+		return ctxCurry[T](prev)
+	}
+}
+
+// withContextOpt ... append option of context to opts without modifying opts.
+func withContextOpt[T any](opts []T, opt T) []T {
+	return append(opts[:len(opts):len(opts)], opt)
+}
+
+// ConvContext ... Conv which stops when ctx is done. return converted elements before it with *PartialError.
+func ConvContext[S, D any](ctx context.Context, srcs []S, convfn ConvFunc[S, D], opts ...Opt[CtxOpt]) (dsts []D, err error) {
+	dsts = Conv(srcs, convfn, withContextOpt(opts, WithContext[CtxOpt](ctx, &err))...)
+	return
+}
+
+// InjectContext ... Inject which stops when ctx is done. return the result of processed elements with *PartialError.
+func InjectContext[T any, V any](ctx context.Context, s []T, injectFn InjectFn[T, V], opts ...OptCurry[V]) (v V, err error) {
+	v = Inject(s, injectFn, withContextOpt(opts, ContextCurry[V](ctx, &err))...)
+	return
+}
+
+// IntersectContext ... Intersect which stops when ctx is done. return the result of processed elements with *PartialError.
+func IntersectContext[T comparable](ctx context.Context, slice1, slice2 []T, opts ...Opt[IntersectOpt]) (result []T, err error) {
+	result = Intersect(slice1, slice2, withContextOpt(opts, WithContext[IntersectOpt](ctx, &err))...)
+	return
+}
+
+// SubContext ... Sub which stops when ctx is done. return the result of processed elements with *PartialError.
+func SubContext[T comparable](ctx context.Context, slice1, slice2 []T, opts ...Opt[SubOpt]) (result []T, err error) {
+	result = Sub(slice1, slice2, withContextOpt(opts, WithContext[SubOpt](ctx, &err))...)
+	return
+}
+
+// ctxCond2 ... merge fns into one condition function checking context.
+// after canceled, it returns true to keep the rest elements.
+func ctxCond2[T any](c *ctxOpt, fns ...CondFunc2[T]) (CondFunc2[T], func() error) {
+
+	var err error
+	cnt := 0
+	return func(t *T) bool {
+			if err != nil {
+				return true
+			}
+			if err = c.canceled(cnt); err != nil {
+				return true
+			}
+			cnt++
+			return matchAll(t, fns...)
+		}, func() error {
+			return err
+		}
+}
+
+// ctxCond ... CondFunc variant of ctxCond2
+func ctxCond(c *ctxOpt, fns ...CondFunc) (CondFunc, func() error) {
+
+	var err error
+	cnt := 0
+	return func(i int) bool {
+			if err != nil {
+				return true
+			}
+			if err = c.canceled(cnt); err != nil {
+				return true
+			}
+			cnt++
+			for _, fn := range fns {
+				if !fn(i) {
+					return false
+				}
+			}
+			return true
+		}, func() error {
+			return err
+		}
+}
@@ -6,6 +6,7 @@ import (
 
 type curryParam[T any] struct {
 	Default T
+	ctxOpt
 }
 
 // OptCurry ... functional option
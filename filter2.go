@@ -1,6 +1,9 @@
 package loncha
 
-import "reflect"
+import (
+	"reflect"
+	"unsafe"
+)
 
 type CondFunc2[T any] func(t *T) bool
 
@@ -19,6 +22,7 @@ type FilterOpt[T any] struct {
 	condFns2      []CondFunc2[T]
 	fVersion      int
 	equalObject   T
+	ctxOpt
 }
 
 func (fopt *FilterOpt[T]) condFn(fns ...CondFunc) (prev []CondFunc) {
@@ -69,6 +73,11 @@ type condFuncSetter2[T any, S any] interface {
 	*T
 }
 
+// FILTER_VERSION_ADAPTIVE ... value of FilterVersion() to choose compaction algorithm by sampling.
+// each element is evaluated once, but sampled blocks spread over slice are evaluated before the others.
+// so condition functions must be pure, or at least must not depend on the order of evaluation.
+const FILTER_VERSION_ADAPTIVE int = -1
+
 func FilterVersion[T any, PT fVersionSetter[T]](v int) Opt[T] {
 
 	return func(p *opParam[T]) Opt[T] {
@@ -109,35 +118,74 @@ func Filter[T comparable](slice []T, condFn CondFunc2[T], opts ...Opt[FilterOpt[
 
 	eq := new(T)
 	if opt.Param.equalObject != *eq {
-		innerFilter2(&slice, true,
-			func(t *T) bool {
-				return *t == opt.Param.equalObject
-			})
+		fns, ctxErr := opt.Param.withCtx2(func(t *T) bool {
+			return *t == opt.Param.equalObject
+		})
+		innerFilter2(&slice, true, fns...)
+		if err := ctxErr(); err != nil {
+			return slice, err
+		}
 	}
 	if condFn != nil {
-		innerFilter2(&slice, true, condFn)
-		return slice, nil
+		if opt.Param.fVersion == FILTER_VERSION_ADAPTIVE {
+			err := filterAdaptive(&slice, &opt.Param, condFn)
+			return slice, err
+		}
+		fns, ctxErr := opt.Param.withCtx2(condFn)
+		innerFilter2(&slice, true, fns...)
+		return slice, ctxErr()
 	}
 
 	if len(opt.Param.condFns) > 0 {
-		err := OldFilter(&slice, opt.Param.condFns...)
-		return slice, err
+		fns, ctxErr := opt.Param.withCtx(opt.Param.condFns...)
+		if err := OldFilter(&slice, fns...); err != nil {
+			return slice, err
+		}
+		return slice, ctxErr()
 	}
 	if len(opt.Param.condFns2) > 0 {
+		if opt.Param.fVersion == FILTER_VERSION_ADAPTIVE {
+			err := filterAdaptive(&slice, &opt.Param, opt.Param.condFns2...)
+			return slice, err
+		}
+		fns, ctxErr := opt.Param.withCtx2(opt.Param.condFns2...)
 		switch opt.Param.fVersion {
 		case 3:
-			innerFilter3(&slice, true, opt.Param.condFns2...)
+			innerFilter3(&slice, true, fns...)
 		case 4:
-			innerFilter4(&slice, true, opt.Param.condFns2...)
+			innerFilter4(&slice, true, fns...)
 		default:
-			innerFilter2(&slice, true, opt.Param.condFns2...)
+			innerFilter2(&slice, true, fns...)
 		}
-		return slice, nil
+		return slice, ctxErr()
 	}
 
 	return slice, nil
 }
 
+func noCtxErr() error {
+	return nil
+}
+
+// withCtx2 ... if context is set, merge fns into one function checking context.
+// the rest elements after canceled are kept.
+func (fopt *FilterOpt[T]) withCtx2(fns ...CondFunc2[T]) ([]CondFunc2[T], func() error) {
+	if fopt.ctx == nil {
+		return fns, noCtxErr
+	}
+	fn, ctxErr := ctxCond2(&fopt.ctxOpt, fns...)
+	return []CondFunc2[T]{fn}, ctxErr
+}
+
+// withCtx ... CondFunc variant of withCtx2
+func (fopt *FilterOpt[T]) withCtx(fns ...CondFunc) ([]CondFunc, func() error) {
+	if fopt.ctx == nil {
+		return fns, noCtxErr
+	}
+	fn, ctxErr := ctxCond(&fopt.ctxOpt, fns...)
+	return []CondFunc{fn}, ctxErr
+}
+
 func innerFilter2[T any](pslice *[]T, keep bool, funcs ...CondFunc2[T]) {
 
 	length := len(*pslice)
@@ -181,6 +229,160 @@ func innerFilter2[T any](pslice *[]T, keep bool, funcs ...CondFunc2[T]) {
 
 }
 
+const (
+	adaptiveSampleBlocks    int = 8
+	adaptiveSampleBlockSize int = 64
+	// ratio of cost between swap of a kept element and memmove per byte of innerFilter3
+	adaptiveSwapCostPerByte int = 256
+)
+
+// innerFilterAdaptive ... choose compaction of innerFilter2 or innerFilter3 by sampling blocks spread over slice.
+// innerFilter2 costs a swap per kept element, innerFilter3 costs moving the tail per run of dropped elements.
+// conditions of sampled elements are reused, so each element is evaluated once.
+// innerFilter4 is not chosen, because it drops the elements after the last run.
+// if c is canceled, the rest elements are kept.
+func innerFilterAdaptive[T any](pslice *[]T, keep bool, c *ctxOpt, funcs ...CondFunc2[T]) (err error) {
+
+	slice := *pslice
+	length := len(slice)
+
+	if length == 0 {
+		return nil
+	}
+	useFilter3, runs, ranges := adaptiveSample(slice, keep, funcs...)
+
+	skiplist := make([]filterRange, 0, runs)
+	r, next := 0, length
+	if len(ranges) > 0 {
+		next = ranges[0].start
+	}
+
+	for i := 0; i < length; i++ {
+		if c.ctx != nil {
+			// the rest elements are kept
+			if err = c.canceled(i); err != nil {
+				break
+			}
+		}
+		var matched bool
+		if i < next {
+			matched = matchAll(&slice[i], funcs...)
+		} else {
+			sampled := &ranges[r]
+			matched = sampled.matched[i-sampled.start]
+			if i+1 == sampled.start+len(sampled.matched) {
+				r++
+				next = length
+				if r < len(ranges) {
+					next = ranges[r].start
+				}
+			}
+		}
+		if matched == keep {
+			continue
+		}
+		if n := len(skiplist); n > 0 && skiplist[n-1].End+1 == i {
+			skiplist[n-1].End++
+			continue
+		}
+		skiplist = append(skiplist, filterRange{i, i})
+	}
+
+	if useFilter3 {
+		purgeBySkiplist(pslice, skiplist)
+		return
+	}
+	swapBySkiplist(pslice, skiplist)
+	return
+}
+
+// swapBySkiplist ... remove ranges of skiplist from slice by swapping each rest element as innerFilter2.
+func swapBySkiplist[T any](pslice *[]T, skiplist []filterRange) {
+
+	if len(skiplist) == 0 {
+		return
+	}
+
+	slice := *pslice
+	newIdx := skiplist[0].Start
+
+	for k, v := range skiplist {
+		end := len(slice)
+		if k+1 < len(skiplist) {
+			end = skiplist[k+1].Start
+		}
+		for i := v.End + 1; i < end; i++ {
+			slice[newIdx], slice[i] = slice[i], slice[newIdx]
+			newIdx++
+		}
+	}
+
+	*pslice = slice[:newIdx]
+}
+
+// filterAdaptive ... innerFilterAdaptive with context of fopt. context is checked after sampling,
+// so PartialError.Processed is the number of elements from the first.
+func filterAdaptive[T comparable](pslice *[]T, fopt *FilterOpt[T], funcs ...CondFunc2[T]) error {
+	return innerFilterAdaptive(pslice, true, &fopt.ctxOpt, funcs...)
+}
+
+// sampledRange ... results of condition of slice[start:start+len(matched)]
+type sampledRange struct {
+	start   int
+	matched []bool
+}
+
+// adaptiveSample ... sample slice and return true if innerFilter3 is faster than innerFilter2.
+// runs is the estimated number of runs of dropped elements in slice.
+// ranges are results of sampled elements in order of index.
+func adaptiveSample[T any](slice []T, keep bool, funcs ...CondFunc2[T]) (useFilter3 bool, runs int, ranges []sampledRange) {
+
+	length := len(slice)
+	// blocks may overlap in short slice. the same index is evaluated once.
+	match := func(i int) bool {
+		if n := len(ranges); n > 0 {
+			last := &ranges[n-1]
+			if end := last.start + len(last.matched); i < end {
+				return last.matched[i-last.start]
+			} else if i == end {
+				last.matched = append(last.matched, matchAll(&slice[i], funcs...))
+				return last.matched[i-last.start]
+			}
+		}
+		ranges = append(ranges, sampledRange{start: i, matched: []bool{matchAll(&slice[i], funcs...)}})
+		return ranges[len(ranges)-1].matched[0]
+	}
+
+	kept, sampled := 0, 0
+	blocks := min(adaptiveSampleBlocks, (length+adaptiveSampleBlockSize-1)/adaptiveSampleBlockSize)
+	for b := 0; b < blocks; b++ {
+		first := b * (length / blocks)
+		end := min(first+adaptiveSampleBlockSize, length)
+		// element before the block decides whether the first drop continues a run
+		prevDropped := false
+		for i := max(first-1, 0); i < end; i++ {
+			allok := match(i) == keep
+			if i < first {
+				prevDropped = !allok
+				continue
+			}
+			sampled++
+			if allok {
+				kept++
+			} else if !prevDropped {
+				runs++
+			}
+			prevDropped = !allok
+		}
+	}
+
+	// (runs * length/sampled) * length * size < (kept * length/sampled) * cost
+	size := int(unsafe.Sizeof(*new(T)))
+	useFilter3 = runs*length*size < kept*adaptiveSwapCostPerByte
+	runs = runs * length / sampled
+	return
+}
+
 type filterRange struct {
 	Start int
 	End   int
@@ -266,7 +468,6 @@ func innerFilter4[T comparable](pslice *[]T, keep bool, funcs ...CondFunc2[T]) {
 
 func innerFilter3[T comparable](pslice *[]T, keep bool, funcs ...CondFunc2[T]) {
 
-	slice := *pslice
 	length := len(*pslice)
 
 	if length == 0 {
@@ -299,8 +500,17 @@ func innerFilter3[T comparable](pslice *[]T, keep bool, funcs ...CondFunc2[T]) {
 		skiplist = append(skiplist, filterRange{i, i})
 	}
 
+	purgeBySkiplist(pslice, skiplist)
+
+}
+
+// purgeBySkiplist ... remove ranges of skiplist from slice by moving the rest.
+func purgeBySkiplist[T any](pslice *[]T, skiplist []filterRange) {
+
+	slice := *pslice
+
 	// not perged
-	if len(skiplist) == length {
+	if len(skiplist) == len(slice) {
 		return
 	}
 
@@ -12,6 +12,7 @@
 package loncha
 
 // Find is value of slice if fn is true. if slice is not pointer of slice or empty, return error
+// Find2 is faster variant without reflection.
 func Find(slice interface{}, fn CondFunc) (interface{}, error) {
 
 	idx, err := IndexOf(slice, fn)
@@ -26,7 +27,7 @@ func Find(slice interface{}, fn CondFunc) (interface{}, error) {
 }
 
 // IndexOf gets the index at which the first match fn is true. if not found. return -1.
-// return error if slice is not pointer of the slice.
+// return error if slice is not pointer of the slice. IndexOf2 is faster variant without reflection.
 func IndexOf(slice interface{}, fn CondFunc) (int, error) {
 
 	rv, err := sliceElm2Reflect(slice)
@@ -47,7 +48,7 @@ func IndexOf(slice interface{}, fn CondFunc) (int, error) {
 }
 
 // LastIndexOf gets the last index at which the last match fn is true. if not found. return -1.
-// return error if slice is not pointer of the slice.
+// return error if slice is not pointer of the slice. LastIndexOf2 is faster variant without reflection.
 func LastIndexOf(slice interface{}, fn CondFunc) (int, error) {
 
 	rv, err := sliceElm2Reflect(slice)
@@ -67,7 +68,7 @@ func LastIndexOf(slice interface{}, fn CondFunc) (int, error) {
 	return -1, ERR_NOT_FOUND
 }
 
-// Contain get return true which fn condition is true.
+// Contain get return true which fn condition is true. Contain2 is faster variant without reflection.
 func Contain(slice interface{}, fn CondFunc) bool {
 
 	idx, err := IndexOf(slice, fn)
@@ -81,3 +82,80 @@ func Contain(slice interface{}, fn CondFunc) bool {
 	return true
 
 }
+
+// Find2 ... Find implementation with type parameters. return value, index of first match fn is true.
+// if not found, return ERR_NOT_FOUND.
+func Find2[T any](slice []T, fn CondFunc2[T]) (v T, idx int, err error) {
+
+	if idx, err = IndexOf2(slice, fn); err != nil {
+		return v, idx, err
+	}
+	return slice[idx], idx, nil
+}
+
+// FindWithIndex ... index variant of Find2
+func FindWithIndex[T any](slice []T, fn CondFuncWithIndex[T]) (v T, idx int, err error) {
+
+	if idx, err = IndexOfWithIndex(slice, fn); err != nil {
+		return v, idx, err
+	}
+	return slice[idx], idx, nil
+}
+
+// IndexOf2 ... IndexOf implementation with type parameters. if not found, return -1 and ERR_NOT_FOUND.
+func IndexOf2[T any](slice []T, fn CondFunc2[T]) (int, error) {
+
+	for i := range slice {
+		if fn(&slice[i]) {
+			return i, nil
+		}
+	}
+	return -1, ERR_NOT_FOUND
+}
+
+// IndexOfWithIndex ... index variant of IndexOf2
+func IndexOfWithIndex[T any](slice []T, fn CondFuncWithIndex[T]) (int, error) {
+
+	for i := range slice {
+		if fn(i, &slice[i]) {
+			return i, nil
+		}
+	}
+	return -1, ERR_NOT_FOUND
+}
+
+// LastIndexOf2 ... LastIndexOf implementation with type parameters. if not found, return -1 and ERR_NOT_FOUND.
+func LastIndexOf2[T any](slice []T, fn CondFunc2[T]) (int, error) {
+
+	for i := len(slice) - 1; i >= 0; i-- {
+		if fn(&slice[i]) {
+			return i, nil
+		}
+	}
+	return -1, ERR_NOT_FOUND
+}
+
+// LastIndexOfWithIndex ... index variant of LastIndexOf2
+func LastIndexOfWithIndex[T any](slice []T, fn CondFuncWithIndex[T]) (int, error) {
+
+	for i := len(slice) - 1; i >= 0; i-- {
+		if fn(i, &slice[i]) {
+			return i, nil
+		}
+	}
+	return -1, ERR_NOT_FOUND
+}
+
+// Contain2 ... Contain implementation with type parameters.
+func Contain2[T any](slice []T, fn CondFunc2[T]) bool {
+
+	_, err := IndexOf2(slice, fn)
+	return err == nil
+}
+
+// ContainWithIndex ... index variant of Contain2
+func ContainWithIndex[T any](slice []T, fn CondFuncWithIndex[T]) bool {
+
+	_, err := IndexOfWithIndex(slice, fn)
+	return err == nil
+}
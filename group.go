@@ -0,0 +1,79 @@
+package loncha
+
+// Group ... elements of same key generated by GroupBy()
+type Group[K comparable, T any] struct {
+	Key    K
+	Values []T
+}
+
+// KeyCount ... the number of elements of same key generated by CountBy()
+type KeyCount[K comparable] struct {
+	Key   K
+	Count int
+}
+
+// GroupBy ... bucket elements by keyFn. groups are returned in order of first appearance.
+func GroupBy[T any, K comparable](s []T, keyFn func(*T) K) []Group[K, T] {
+	return GroupByInto(s, keyFn, nil)
+}
+
+// GroupByInto ... same as GroupBy, but reuse dsts and Values of it as storage of result.
+func GroupByInto[T any, K comparable](s []T, keyFn func(*T) K, dsts []Group[K, T]) []Group[K, T] {
+
+	idxOfKey := map[K]int{}
+	dsts = dsts[:0]
+
+	for i := range s {
+		key := keyFn(&s[i])
+		idx, found := idxOfKey[key]
+		if !found {
+			idx = len(dsts)
+			idxOfKey[key] = idx
+			if idx < cap(dsts) {
+				dsts = dsts[:idx+1]
+				dsts[idx].Key = key
+				dsts[idx].Values = dsts[idx].Values[:0]
+			} else {
+				dsts = append(dsts, Group[K, T]{Key: key})
+			}
+		}
+		dsts[idx].Values = append(dsts[idx].Values, s[i])
+	}
+	return dsts
+}
+
+// CountBy ... count elements by keyFn. counts are returned in order of first appearance.
+func CountBy[T any, K comparable](s []T, keyFn func(*T) K) (cnts []KeyCount[K]) {
+
+	idxOfKey := map[K]int{}
+	cnts = []KeyCount[K]{}
+
+	for i := range s {
+		key := keyFn(&s[i])
+		idx, found := idxOfKey[key]
+		if !found {
+			idx = len(cnts)
+			idxOfKey[key] = idx
+			cnts = append(cnts, KeyCount[K]{Key: key})
+		}
+		cnts[idx].Count++
+	}
+	return
+}
+
+// GroupBySorted ... group runs of same key in sorted slice without hashing.
+// Values of each group share the memory of s.
+func GroupBySorted[T any, V Ordered](s []T, IdentFn IdentFunc[T, V]) (groups []Group[V, T]) {
+
+	groups = []Group[V, T]{}
+
+	start := 0
+	for i := 1; i <= len(s); i++ {
+		if i < len(s) && IdentFn(s, i) == IdentFn(s, start) {
+			continue
+		}
+		groups = append(groups, Group[V, T]{Key: IdentFn(s, start), Values: s[start:i:i]})
+		start = i
+	}
+	return
+}
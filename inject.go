@@ -4,10 +4,20 @@ package loncha
 type InjectFn[T any, R any] func(R, T) R
 
 // Inject ... return an object formed from operands via InjectFn
+// with ContextCurry(), stop when context is done and return the result of processed elements.
 func Inject[T any, V any](s []T, injectFn InjectFn[T, V], opts ...OptCurry[V]) (v V) {
 	if len(opts) > 0 {
 		p := NewOpt(opts...)
 		v = p.Default
+		if p.ctx != nil {
+			for i, t := range s {
+				if p.canceled(i) != nil {
+					return
+				}
+				v = injectFn(v, t)
+			}
+			return
+		}
 	}
 	for _, t := range s {
 		v = injectFn(v, t)
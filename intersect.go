@@ -6,25 +6,55 @@ package loncha
 import "sort"
 
 type IntersectOpt struct {
-	Uniq bool
+	Uniq  bool
+	isBag bool
+	ctxOpt
+}
+
+func (iopt *IntersectOpt) bag(v bool) (prev bool) {
+	prev = iopt.isBag
+	iopt.isBag = v
+	return prev
+}
+
+type bagSetter[T any] interface {
+	bag(bool) bool
+	*T
+}
+
+// Bag ... treat slices as multiset(bag). the count of each element is considered.
+func Bag[T any, PT bagSetter[T]](v bool) Opt[T] {
+	return func(p *opParam[T]) Opt[T] {
+		prev := PT(&p.Param).bag(v)
+		return Bag[T, PT](prev)
+	}
 }
 
 // Intersect ... intersection between 2 slice.
+// with Bag[IntersectOpt](true), each element is kept min(count in slice1, count in slice2) times.
+// with WithContext[IntersectOpt](), stop when context is done and return the result of processed elements of slice2.
+// slice1 is hashed before slice2, so PartialError.Processed counts elements of slice1 first.
 func Intersect[T comparable](slice1, slice2 []T, opts ...Opt[IntersectOpt]) (result []T) {
 
 	param, fn := MergeOpts(opts...)
 	defer fn(param)
 
-	exists := map[T]bool{}
-	already := map[T]bool{}
+	if param.Param.isBag && !param.Param.Uniq {
+		return intersectBag(slice1, slice2, &param.Param.ctxOpt)
+	}
 
-	for _, v := range slice1 {
-		exists[v] = true
+	exists, err := mapOfExistsCtx(slice1, &param.Param.ctxOpt)
+	if err != nil {
+		return []T{}
 	}
+	already := map[T]bool{}
 
 	result = make([]T, 0, len(exists))
 
-	for _, v := range slice2 {
+	for i, v := range slice2 {
+		if param.Param.ctx != nil && param.Param.canceled(len(slice1)+i) != nil {
+			return
+		}
 		if param.Param.Uniq && already[v] {
 			continue
 		}
@@ -37,6 +67,50 @@ func Intersect[T comparable](slice1, slice2 []T, opts ...Opt[IntersectOpt]) (res
 	return
 }
 
+func intersectBag[T comparable](slice1, slice2 []T, c *ctxOpt) (result []T) {
+
+	cnts, err := mapOfCountsCtx(slice1, c)
+	if err != nil {
+		return []T{}
+	}
+	result = make([]T, 0, min(len(slice1), len(slice2)))
+
+	for i, v := range slice2 {
+		if c.ctx != nil && c.canceled(len(slice1)+i) != nil {
+			return
+		}
+		if cnts[v] > 0 {
+			result = append(result, v)
+			cnts[v]--
+		}
+	}
+	return
+}
+
+// IntersectBy ... intersection between two slices by key of keyFn. T doesn't need to be comparable.
+// order of left is kept. use RightWin[ByOpt](true) to get element of right.
+func IntersectBy[T any, K comparable](left, right []T, keyFn func(*T) K, opts ...Opt[ByOpt]) (result []T) {
+
+	param, fn := MergeOpts(opts...)
+	defer fn(param)
+
+	exists := mapOfIndexBy(right, keyFn)
+	result = make([]T, 0, min(len(left), len(right)))
+
+	for i := range left {
+		j, found := exists[keyFn(&left[i])]
+		if !found {
+			continue
+		}
+		if param.Param.isRightWin {
+			result = append(result, right[j])
+		} else {
+			result = append(result, left[i])
+		}
+	}
+	return
+}
+
 // Ordered ... copy from https://github.com/golang/go/blob/go1.18.3/test/typeparam/ordered.go
 type Ordered interface {
 	~int | ~int8 | ~int16 | ~int32 | ~int64 |
@@ -0,0 +1,100 @@
+package loncha
+
+// JoinFunc ... combine function of joined elements. r is nil if no element of right is matched in LeftJoin.
+type JoinFunc[L, R, D any] func(l *L, r *R) D
+
+// mapOfIndexesBy ... map of key to indexes of elements.
+func mapOfIndexesBy[T any, K comparable](slice []T, keyFn func(*T) K) (idxes map[K][]int) {
+
+	idxes = make(map[K][]int, len(slice))
+	for i := range slice {
+		key := keyFn(&slice[i])
+		idxes[key] = append(idxes[key], i)
+	}
+	return
+}
+
+// InnerJoin ... hash join left and right by key of lKey/rKey, and return combined results.
+// results are in order of left, and matched elements of right are in order of right.
+func InnerJoin[L, R any, K comparable, D any](left []L, right []R, lKey func(*L) K, rKey func(*R) K, combine JoinFunc[L, R, D]) []D {
+	return hashJoin(left, right, lKey, rKey, combine, false)
+}
+
+// LeftJoin ... same as InnerJoin, but element of left which is not matched is combined with nil.
+func LeftJoin[L, R any, K comparable, D any](left []L, right []R, lKey func(*L) K, rKey func(*R) K, combine JoinFunc[L, R, D]) []D {
+	return hashJoin(left, right, lKey, rKey, combine, true)
+}
+
+func hashJoin[L, R any, K comparable, D any](left []L, right []R, lKey func(*L) K, rKey func(*R) K, combine JoinFunc[L, R, D], isOuter bool) (results []D) {
+
+	idxes := mapOfIndexesBy(right, rKey)
+	results = make([]D, 0, len(left))
+
+	for i := range left {
+		matched := idxes[lKey(&left[i])]
+		if len(matched) == 0 && isOuter {
+			results = append(results, combine(&left[i], nil))
+		}
+		for _, j := range matched {
+			results = append(results, combine(&left[i], &right[j]))
+		}
+	}
+	return
+}
+
+// SemiJoin ... return elements of left which key exists in right.
+func SemiJoin[L, R any, K comparable](left []L, right []R, lKey func(*L) K, rKey func(*R) K) []L {
+	return semiJoin(left, right, lKey, rKey, true)
+}
+
+// AntiJoin ... return elements of left which key does not exist in right.
+func AntiJoin[L, R any, K comparable](left []L, right []R, lKey func(*L) K, rKey func(*R) K) []L {
+	return semiJoin(left, right, lKey, rKey, false)
+}
+
+func semiJoin[L, R any, K comparable](left []L, right []R, lKey func(*L) K, rKey func(*R) K, exist bool) (results []L) {
+
+	exists := mapOfIndexBy(right, rKey)
+	results = make([]L, 0, len(left))
+
+	for i := range left {
+		if _, found := exists[lKey(&left[i])]; found == exist {
+			results = append(results, left[i])
+		}
+	}
+	return
+}
+
+// InnerJoinSorted ... sort-merge variant of InnerJoin. left and right must be sorted by key of IdentFunc.
+func InnerJoinSorted[L, R any, V Ordered, D any](left []L, right []R, lIdent IdentFunc[L, V], rIdent IdentFunc[R, V], combine JoinFunc[L, R, D]) []D {
+	return mergeJoin(left, right, lIdent, rIdent, combine, false)
+}
+
+// LeftJoinSorted ... sort-merge variant of LeftJoin. left and right must be sorted by key of IdentFunc.
+func LeftJoinSorted[L, R any, V Ordered, D any](left []L, right []R, lIdent IdentFunc[L, V], rIdent IdentFunc[R, V], combine JoinFunc[L, R, D]) []D {
+	return mergeJoin(left, right, lIdent, rIdent, combine, true)
+}
+
+func mergeJoin[L, R any, V Ordered, D any](left []L, right []R, lIdent IdentFunc[L, V], rIdent IdentFunc[R, V], combine JoinFunc[L, R, D], isOuter bool) (results []D) {
+
+	results = make([]D, 0, len(left))
+
+	j := 0
+	for i := range left {
+		key := lIdent(left, i)
+		for j < len(right) && rIdent(right, j) < key {
+			j++
+		}
+		end := j
+		for end < len(right) && rIdent(right, end) == key {
+			end++
+		}
+		if j == end && isOuter {
+			results = append(results, combine(&left[i], nil))
+		}
+		for k := j; k < end; k++ {
+			results = append(results, combine(&left[i], &right[k]))
+		}
+	}
+	return
+}
@@ -1,10 +1,14 @@
 package loncha
 
 import (
+	"context"
+	"encoding/binary"
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
 	"sort"
+	"strconv"
 	"testing"
 
 	"github.com/thoas/go-funk"
@@ -112,6 +116,42 @@ func TestWhere(t *testing.T) {
 	t.Logf("nSlice.len=%d cap=%d\n", len(nSlice), cap(nSlice))
 }
 
+func TestQuery(t *testing.T) {
+	objs := []Element{{5, "e"}, {1, "a"}, {3, "c"}, {2, "b"}, {4, "d"}, {3, "cc"}}
+	id := func(e *Element) int { return e.ID }
+	name := func(e Element) (string, bool) { return e.Name, false }
+
+	assert.Equal(t, []string{"cc", "c", "b"},
+		QuerySelect(
+			From(objs).
+				Where(func(e *Element) bool { return e.ID < 5 }).
+				OrderBy(By(id), true).
+				OrderBy(By(func(e *Element) string { return e.Name }), true).
+				Offset(1).Limit(3),
+			name))
+	assert.Equal(t, []Element{{5, "e"}, {1, "a"}, {3, "c"}, {2, "b"}, {4, "d"}, {3, "cc"}}, objs)
+
+	assert.Equal(t, []string{"c", "b"},
+		QuerySelect(From(objs).Where(func(e *Element) bool { return e.ID < 4 }).Offset(1).Limit(2), name))
+	assert.Equal(t, 6, From(objs).Count())
+	assert.Equal(t, 0, From(objs).Limit(0).Count())
+
+	groups := QueryGroupBy(From(objs).Where(func(e *Element) bool { return e.ID > 1 }),
+		func(e *Element) bool { return e.ID%2 == 0 }).
+		Having(func(g *Group[bool, Element]) bool { return len(g.Values) > 1 }).
+		OrderBy(By(func(g *Group[bool, Element]) int { return len(g.Values) }), false).
+		All()
+	assert.Equal(t, 2, len(groups))
+	assert.Equal(t, true, groups[0].Key)
+	assert.Equal(t, 3, len(groups[1].Values))
+
+	first, err := From(objs).OrderBy(By(id), false).First()
+	assert.NoError(t, err)
+	assert.Equal(t, "a", first.Name)
+	_, err = From(objs).Where(func(e *Element) bool { return false }).First()
+	assert.Equal(t, ERR_NOT_FOUND, err)
+}
+
 func TestFind(t *testing.T) {
 	nSlice := Elements(MakeSliceSample())
 	id := nSlice[50].ID
@@ -156,6 +196,57 @@ func TestLastIndexOf(t *testing.T) {
 
 }
 
+func TestFind2(t *testing.T) {
+	nSlice := Elements(MakeSliceSample())
+	// IDs of MakeSliceSample() are less than CREATE_SLICE_MAX, so the ID at index 50 is unique
+	nSlice[50].ID = CREATE_SLICE_MAX
+	id := nSlice[50].ID
+
+	elm, idx, err := Find2(nSlice, func(e *Element) bool {
+		return e.ID == id
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, id, elm.ID)
+	assert.Equal(t, 50, idx)
+
+	elm, idx, err = FindWithIndex(nSlice, func(i int, e *Element) bool {
+		return i >= 50 && e.ID == id
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, id, elm.ID)
+	assert.Equal(t, 50, idx)
+
+	_, idx, err = FindWithIndex(nSlice, func(i int, e *Element) bool {
+		return i > 50 && e.ID == id
+	})
+	assert.Equal(t, ERR_NOT_FOUND, err)
+	assert.Equal(t, -1, idx)
+
+	last, err := LastIndexOf2(nSlice, func(e *Element) bool {
+		return e.ID == id
+	})
+	assert.NoError(t, err)
+	assert.True(t, last >= 50, last)
+
+	last2, err := LastIndexOfWithIndex(nSlice, func(i int, e *Element) bool {
+		return e.ID == id
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, last, last2)
+
+	_, idx, err = Find2(nSlice, func(e *Element) bool {
+		return e.ID < 0
+	})
+	assert.Equal(t, ERR_NOT_FOUND, err)
+	assert.Equal(t, -1, idx)
+
+	slice1 := []int{10, 6, 4, 2}
+	assert.True(t, Contain2(slice1, has(6)))
+	assert.False(t, Contain2(slice1, has(11)))
+	assert.True(t, ContainWithIndex(slice1, func(i int, v *int) bool { return i == 3 && *v == 2 }))
+	assert.False(t, Contain2([]int{}, has(1)))
+}
+
 func TestFilter(t *testing.T) {
 	nSlice := Elements(MakeSliceSample())
 	id := nSlice[50].ID
@@ -246,6 +337,258 @@ func TestFilter2(t *testing.T) {
 
 }
 
+func TestPartition(t *testing.T) {
+	isEven := func(v *int) bool { return *v%2 == 0 }
+	isSmall := func(v *int) bool { return *v < 50 }
+
+	for n := 0; n < 30; n++ {
+		orig := Step(0, n*5, 5)
+		ShuffleT(orig, Seed[RandOpt](int64(n)))
+
+		expectMatched := Filterable(isEven, isSmall)(append([]int{}, orig...))
+		expectRest := Convertable(func(v int) (int, bool) {
+			return v, isEven(&v) && isSmall(&v)
+		})(orig)
+
+		slice := append([]int{}, orig...)
+		matched, rest := Partition(slice, isEven, isSmall)
+		assert.Equal(t, expectMatched, append([]int{}, matched...))
+		assert.Equal(t, expectRest, append([]int{}, rest...))
+		assert.Equal(t, len(orig), len(matched)+len(rest))
+
+		slice = append([]int{}, orig...)
+		matched, rest = PartitionUnstable(slice, isEven, isSmall)
+		assert.Equal(t, Sort(expectMatched), Sort(append([]int{}, matched...)))
+		assert.Equal(t, Sort(expectRest), Sort(append([]int{}, rest...)))
+	}
+
+	matched, rest := Partition([]int{1, 2, 3}, isEven)
+	matched = append(matched, 10)
+	assert.Equal(t, []int{1, 3}, rest)
+}
+
+func TestFilterAdaptive(t *testing.T) {
+	orig := Step(0, 10000, 1)
+
+	for _, cond := range []CondFunc2[int]{
+		func(v *int) bool { return *v%1000 != 0 },
+		func(v *int) bool { return *v%100 == 0 },
+		func(v *int) bool { return *v < 2000 || *v >= 5000 },
+		func(v *int) bool { return *v != 9999 },
+		func(v *int) bool { return false },
+		func(v *int) bool { return true },
+	} {
+		expect := Filterable(cond)(append([]int{}, orig...))
+
+		result, err := Filter(append([]int{}, orig...), nil,
+			FilterVersion[FilterOpt[int]](FILTER_VERSION_ADAPTIVE),
+			Cond2[FilterOpt[int]](cond))
+		assert.NoError(t, err)
+		assert.Equal(t, expect, result)
+
+		result, _ = Filter(append([]int{}, orig[:100]...), cond,
+			FilterVersion[FilterOpt[int]](FILTER_VERSION_ADAPTIVE))
+		assert.Equal(t, Filterable(cond)(append([]int{}, orig[:100]...)), result)
+	}
+
+	// each element is evaluated once
+	for _, n := range []int{100, 1000, len(orig)} {
+		cnt := 0
+		result, _ := Filter(append([]int{}, orig[:n]...), func(v *int) bool {
+			cnt++
+			return *v%3 != 0
+		}, FilterVersion[FilterOpt[int]](FILTER_VERSION_ADAPTIVE))
+		assert.Equal(t, n, cnt)
+		assert.Equal(t, Filterable(func(v *int) bool { return *v%3 != 0 })(append([]int{}, orig[:n]...)), result)
+	}
+}
+
+func TestTry(t *testing.T) {
+	strs := []string{"1", "x", "2", "3", "y", "4"}
+	isEven := func(s *string) (bool, error) {
+		i, err := strconv.Atoi(*s)
+		return i%2 == 0, err
+	}
+
+	result, err := TryFilter(append([]string{}, strs...), isEven)
+	var eErr *ElementError
+	assert.True(t, errors.As(err, &eErr))
+	assert.Equal(t, 1, eErr.Index)
+	assert.Equal(t, []string{"x", "2", "3", "y", "4"}, result)
+
+	result, err = TryFilter(append([]string{}, strs...), isEven, CollectErrors[TryOpt](true))
+	assert.Equal(t, []string{"x", "2", "y", "4"}, result)
+	assert.Equal(t, []string{"", ""}, result[4:6])
+	assert.Equal(t, 2, len(err.(Errors)))
+	assert.True(t, errors.Is(err, strconv.ErrSyntax))
+	var numErr *strconv.NumError
+	assert.True(t, errors.As(err, &numErr))
+	assert.Equal(t, "x", numErr.Num)
+	assert.False(t, errors.Is(err, strconv.ErrRange))
+
+	selected, err := TrySelect(strs, isEven)
+	assert.Error(t, err)
+	assert.Equal(t, []string{}, selected)
+	selected, err = TrySelect(strs, isEven, CollectErrors[TryOpt](true))
+	assert.Error(t, err)
+	assert.Equal(t, []string{"2", "4"}, selected)
+	assert.Equal(t, []string{"1", "x", "2", "3", "y", "4"}, strs)
+
+	atoi := func(s string) (int, bool, error) {
+		i, err := strconv.Atoi(s)
+		return i, false, err
+	}
+	ints, err := TryConv(strs, atoi)
+	assert.Error(t, err)
+	assert.Equal(t, []int{1}, ints)
+	ints, err = TryConv(strs, atoi, CollectErrors[TryOpt](true))
+	assert.Error(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4}, ints)
+	ints, err = TryConv(strs[2:4], atoi)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, ints)
+
+	sum := func(r int, s string) (int, error) {
+		i, err := strconv.Atoi(s)
+		return r + i, err
+	}
+	total, err := TryInject(strs, sum)
+	assert.Error(t, err)
+	assert.Equal(t, 1, total)
+	total, err = TryInject(strs, sum, CollectErrors[TryOpt](true))
+	assert.Equal(t, "index 1: strconv.Atoi: parsing \"x\": invalid syntax\nindex 4: strconv.Atoi: parsing \"y\": invalid syntax", err.Error())
+	assert.Equal(t, 10, total)
+}
+
+// countdownCtx ... context canceled at n-th call of Err()
+type countdownCtx struct {
+	context.Context
+	n int
+}
+
+func (c *countdownCtx) Err() error {
+	if c.n--; c.n <= 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestWithContext(t *testing.T) {
+	nums := Step(0, 5000, 1)
+	even := func(i *int) bool { return *i%2 == 0 }
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// without context
+	r, err := Filter(append([]int{}, nums...), even)
+	assert.NoError(t, err)
+	assert.Equal(t, 2500, len(r))
+
+	// canceled before start. nothing is processed.
+	r, err = Filter(append([]int{}, nums...), even, WithContext[FilterOpt[int]](canceled, nil))
+	assert.True(t, errors.Is(err, context.Canceled))
+	var perr *PartialError
+	assert.True(t, errors.As(err, &perr))
+	assert.Equal(t, 0, perr.Processed)
+	assert.Equal(t, nums, r)
+
+	// canceled while filtering. the rest elements are kept.
+	ctx, cancel := context.WithCancel(context.Background())
+	r, err = Filter(append([]int{}, nums...), func(i *int) bool {
+		if *i == 1500 {
+			cancel()
+		}
+		return *i%2 == 0
+	}, WithContext[FilterOpt[int]](ctx, nil))
+	assert.True(t, errors.As(err, &perr))
+	assert.Equal(t, 2048, perr.Processed)
+	assert.Equal(t, 2046, r[1023])
+	assert.Equal(t, 2048, r[1024])
+	assert.Equal(t, 1024+5000-2048, len(r))
+
+	var cerr error
+	v := Inject(nums, func(sum, i int) int { return sum + i }, ContextCurry[int](canceled, &cerr))
+	assert.Equal(t, 0, v)
+	assert.True(t, errors.Is(cerr, context.Canceled))
+
+	cerr = nil
+	s := Conv(nums, func(i int) (string, bool) { return strconv.Itoa(i), false }, WithContext[CtxOpt](canceled, &cerr))
+	assert.Equal(t, 0, len(s))
+	assert.True(t, errors.Is(cerr, context.Canceled))
+	assert.Equal(t, 5000, len(Conv(nums, func(i int) (string, bool) { return strconv.Itoa(i), false })))
+
+	cerr = nil
+	assert.Equal(t, 0, len(Intersect(nums, nums, WithContext[IntersectOpt](canceled, &cerr))))
+	assert.True(t, errors.Is(cerr, context.Canceled))
+	assert.Equal(t, 5000, len(Intersect(nums, nums, WithContext[IntersectOpt](context.Background(), &cerr))))
+
+	cerr = nil
+	assert.Equal(t, 0, len(Sub(nums, []int{1}, WithContext[SubOpt](canceled, &cerr))))
+	assert.True(t, errors.Is(cerr, context.Canceled))
+
+	// canceled while hashing the other slice
+	ints, err := IntersectContext(&countdownCtx{Context: context.Background(), n: 2}, Step(0, 3000, 1), nums)
+	assert.True(t, errors.As(err, &perr))
+	assert.Equal(t, 1024, perr.Processed)
+	assert.Equal(t, 0, len(ints))
+	ints, err = SubContext(&countdownCtx{Context: context.Background(), n: 4}, nums, Step(0, 3000, 1))
+	assert.True(t, errors.As(err, &perr))
+	assert.Equal(t, 3072, perr.Processed)
+	assert.Equal(t, 0, len(ints))
+	ints, err = SubContext(context.Background(), nums, Step(0, 4000, 1), Bag[SubOpt](true))
+	assert.NoError(t, err)
+	assert.Equal(t, 1000, len(ints))
+	ints, err = SubContext(canceled, nums, Step(0, 4000, 1), Bag[SubOpt](true))
+	assert.True(t, errors.As(err, &perr))
+	assert.Equal(t, 0, perr.Processed)
+	assert.Equal(t, 0, len(ints))
+	ints, err = IntersectContext(canceled, nums, nums, Bag[IntersectOpt](true))
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 0, len(ints))
+
+	// error-returning variants
+	s, err = ConvContext(canceled, nums, func(i int) (string, bool) { return strconv.Itoa(i), false })
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 0, len(s))
+	s, err = ConvContext(context.Background(), nums, func(i int) (string, bool) { return strconv.Itoa(i), false })
+	assert.NoError(t, err)
+	assert.Equal(t, 5000, len(s))
+	v, err = InjectContext(canceled, nums, func(sum, i int) int { return sum + i }, Default(1))
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 1, v)
+	v, err = InjectContext(context.Background(), nums, func(sum, i int) int { return sum + i })
+	assert.NoError(t, err)
+	assert.Equal(t, Sum(nums), v)
+
+	// adaptive filter checks context after sampling, so Processed counts from the first element.
+	for _, at := range []int{1260, 1500} {
+		ctx, cancel = context.WithCancel(context.Background())
+		r, err = Filter(append([]int{}, nums...), func(i *int) bool {
+			if *i == at {
+				cancel()
+			}
+			return *i%2 == 0
+		}, WithContext[FilterOpt[int]](ctx, nil), FilterVersion[FilterOpt[int]](FILTER_VERSION_ADAPTIVE))
+		assert.True(t, errors.As(err, &perr))
+		kept := 0
+		for _, i := range nums[:perr.Processed] {
+			if i%2 == 0 {
+				kept++
+			}
+		}
+		assert.Equal(t, kept+len(nums)-perr.Processed, len(r))
+		assert.Equal(t, nums[perr.Processed:], r[kept:])
+	}
+
+	dup := append(append([]int{}, nums...), nums...)
+	_, err = UniqWithSort(&dup, func(i, j int) bool { return dup[i] < dup[j] }, WithContext[CtxOpt](canceled, nil))
+	assert.True(t, errors.Is(err, context.Canceled))
+	n, err := UniqWithSort(&dup, func(i, j int) bool { return dup[i] < dup[j] }, WithContext[CtxOpt](context.Background(), nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 5000, n)
+}
+
 func TestDelete(t *testing.T) {
 
 	nSlice := Elements(MakeSliceSample())
@@ -331,6 +674,89 @@ func TestUniqWithSort(t *testing.T) {
 
 }
 
+func TestUniqBy(t *testing.T) {
+	objs := []Element{{3, "a"}, {1, "b"}, {3, "c"}, {2, "d"}, {1, "e"}}
+	id := func(e *Element) int { return e.ID }
+
+	assert.Equal(t, []Element{{3, "a"}, {1, "b"}, {2, "d"}}, UniqBy(objs, id))
+	assert.Equal(t, []Element{{3, "c"}, {2, "d"}, {1, "e"}}, UniqBy(objs, id, KeepLast[UniqOpt](true)))
+	assert.Equal(t, []Element{{3, "a"}, {1, "b"}, {3, "c"}, {2, "d"}, {1, "e"}}, objs)
+
+	sorted := make([]Element, len(objs))
+	copy(sorted, objs)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+	ident := func(s []Element, i int) int { return s[i].ID }
+
+	first := UniqSorted(append([]Element{}, sorted...), ident)
+	assert.Equal(t, []Element{{1, "b"}, {2, "d"}, {3, "a"}}, first)
+	assert.Equal(t, UniqBy(sorted, id), first)
+	last := UniqSorted(append([]Element{}, sorted...), ident, KeepLast[UniqOpt](true))
+	assert.Equal(t, []Element{{1, "e"}, {2, "d"}, {3, "c"}}, last)
+	assert.Equal(t, UniqBy(sorted, id, KeepLast[UniqOpt](true)), last)
+
+	inPlace := UniqInPlace(objs, id)
+	assert.Equal(t, []Element{{3, "a"}, {1, "b"}, {2, "d"}}, inPlace)
+	assert.Equal(t, &objs[0], &inPlace[0])
+	assert.Equal(t, Element{}, objs[len(objs)-1])
+
+	ptrs := []*Element{&objs[0], &objs[1], &objs[0]}
+	ptrs = UniqInPlace(ptrs, func(e **Element) int { return (*e).ID })
+	assert.Equal(t, 2, len(ptrs))
+	assert.Nil(t, ptrs[:3][2])
+	assert.Equal(t, 0, len(UniqSorted([]int{}, func(s []int, i int) int { return s[i] })))
+
+	nSlice := MakeSliceSample()
+	nSlice2 := make([]Element, len(nSlice))
+	copy(nSlice2, nSlice)
+	Uniq(&nSlice2, func(i int) interface{} {
+		return nSlice2[i].ID
+	})
+	assert.Equal(t, nSlice2, UniqBy(nSlice, id))
+}
+
+func TestApprox(t *testing.T) {
+	key := func(v *int) []byte {
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, uint64(*v))
+		return b
+	}
+
+	ids := Conv(Step(0, 300000, 1), func(i int) (int, bool) { return i % 100000, false })
+
+	cnt := CountDistinct(ids, key)
+	assert.InDelta(t, 100000, float64(cnt), 3000)
+	assert.InDelta(t, 100, float64(CountDistinct(ids[:100], key)), 3)
+	assert.InDelta(t, 100000, float64(CountDistinct(ids, key, Precision[ApproxOpt](10))), 10000)
+
+	h1 := NewHyperLogLogOf(Step(0, 60000, 1), key)
+	h2 := NewHyperLogLogOf(Step(40000, 100000, 1), key)
+	assert.NoError(t, h1.Merge(h2))
+	assert.InDelta(t, 100000, float64(h1.Count()), 3000)
+	assert.Equal(t, ERR_SKETCH_MISMATCH, h1.Merge(NewHyperLogLog(10)))
+
+	uniq := UniqApprox(append([]int{}, ids...), key)
+	assert.InDelta(t, 100000, len(uniq), 2000)
+	assert.Equal(t, len(uniq), len(UniqBy(uniq, func(v *int) int { return *v })))
+
+	contains := ContainsApprox(Step(0, 10000, 1), key, FalsePositive[ApproxOpt](0.01))
+	for _, v := range Step(0, 10000, 1) {
+		assert.True(t, contains(&v))
+	}
+	fp := len(Filterable(contains)(Step(10000, 110000, 1)))
+	assert.InDelta(t, 1000, fp, 500)
+
+	assert.Equal(t, ERR_SKETCH_MISMATCH, NewBloomFilterOf(Step(0, 100, 1), key).Merge(NewBloomFilterOf(Step(100, 150, 1), key)))
+
+	// shards of different length are merged with same Capacity
+	b1 := NewBloomFilterOf(Step(0, 3000, 1), key, Capacity[ApproxOpt](10000))
+	b2 := NewBloomFilterOf(Step(3000, 10000, 1), key, Capacity[ApproxOpt](10000))
+	assert.NoError(t, b1.Merge(b2))
+	assert.True(t, b1.Contains(key(&[]int{5000}[0])))
+	fp = len(Filterable(func(v *int) bool { return b1.Contains(key(v)) })(Step(10000, 110000, 1)))
+	assert.InDelta(t, 1000, fp, 500)
+	assert.Equal(t, ERR_SKETCH_MISMATCH, b1.Merge(NewBloomFilter(1000, 0.01)))
+}
+
 func TestSelect(t *testing.T) {
 	slice := MakeSliceSample()
 
@@ -401,11 +827,165 @@ func TestShuffle(t *testing.T) {
 	assert.NotEqual(t, slice[0].ID, a.ID)
 }
 
+func TestShuffleT(t *testing.T) {
+	slice := MakeSliceSample()
+	slice2 := make([]Element, len(slice))
+	copy(slice2, slice)
+
+	Shuffle(slice, 2)
+	ShuffleT(slice2, Seed[RandOpt](2))
+	assert.Equal(t, slice, slice2)
+
+	ints := Step(0, 100, 1)
+	ints2 := Step(0, 100, 1)
+	ShuffleT(ints, RandSource[RandOpt](rand.NewSource(1)), Seed[RandOpt](5))
+	ShuffleT(ints2, RandSource[RandOpt](rand.NewSource(2)), Seed[RandOpt](5))
+	assert.Equal(t, ints, ints2)
+	assert.Equal(t, 4950, Sum(ints))
+
+	ints = Step(0, 100, 1)
+	ShuffleN(ints, 10, Seed[RandOpt](3))
+	assert.Equal(t, 4950, Sum(ints))
+	assert.Equal(t, ints[:10], Sample(Step(0, 100, 1), 10, Seed[RandOpt](3)))
+	assert.Equal(t, 100, len(Sample(ints, 200)))
+	assert.Equal(t, 0, len(Sample(ints, -1)))
+
+	sampled := SampleSeq(StepSeq(0, 1000, 1), 10, Seed[RandOpt](7))
+	assert.Equal(t, 10, len(sampled))
+	assert.Equal(t, sampled, SampleSeq(StepSeq(0, 1000, 1), 10, Seed[RandOpt](7)))
+	assert.Equal(t, []int{0, 1, 2}, SampleSeq(StepSeq(0, 3, 1), 10))
+}
+
+type Loot struct {
+	Name   string
+	Weight int
+}
+
+func TestWeighted(t *testing.T) {
+	loots := []Loot{{"common", 70}, {"rare", 25}, {"epic", 5}, {"none", 0}}
+	weight := func(l *Loot) int { return l.Weight }
+
+	chooser, err := NewWeightedChooser(loots, weight, Seed[RandOpt](1))
+	assert.NoError(t, err)
+
+	cnts := map[string]int{}
+	for i := 0; i < 100000; i++ {
+		cnts[chooser.Choice().Name]++
+	}
+	assert.InDelta(t, 70000, cnts["common"], 1500)
+	assert.InDelta(t, 25000, cnts["rare"], 1500)
+	assert.InDelta(t, 5000, cnts["epic"], 1000)
+	assert.Equal(t, 0, cnts["none"])
+
+	cnts = map[string]int{}
+	for i := int64(0); i < 10000; i++ {
+		l, err := WeightedChoice(loots, weight, Seed[RandOpt](i))
+		assert.NoError(t, err)
+		cnts[l.Name]++
+	}
+	assert.InDelta(t, 7000, cnts["common"], 500)
+	assert.Equal(t, 0, cnts["none"])
+
+	sampled, err := WeightedSample(loots, 10, weight, Seed[RandOpt](3))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, len(sampled))
+	again, _ := WeightedSample(loots, 10, weight, Seed[RandOpt](3))
+	assert.Equal(t, sampled, again)
+
+	firsts := map[string]int{}
+	for i := int64(0); i < 10000; i++ {
+		s, _ := WeightedSample(loots, 2, weight, Seed[RandOpt](i))
+		assert.NotEqual(t, s[0], s[1])
+		firsts[s[0].Name]++
+	}
+	assert.InDelta(t, 7000, firsts["common"], 500)
+
+	_, err = WeightedChoice([]Loot{{"bad", -1}}, weight)
+	assert.Equal(t, ERR_INVALID_WEIGHT, err)
+	_, err = NewWeightedChooser([]Loot{{"none", 0}}, weight)
+	assert.Equal(t, ERR_INVALID_WEIGHT, err)
+	_, err = WeightedSample([]Loot{}, 1, weight)
+	assert.Equal(t, ERR_INVALID_WEIGHT, err)
+}
+
 func TestReverse(t *testing.T) {
 	slice := []int{1, 4, 2, 6, 4, 6}
 	Reverse(slice)
 
 	assert.Equal(t, []int{6, 4, 6, 2, 4, 1}, slice)
+
+	var islice interface{} = []string{"a", "b", "c"}
+	OldReverse(islice)
+	assert.Equal(t, []string{"c", "b", "a"}, islice)
+}
+
+func TestPermutation(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for n := 0; n < 20; n++ {
+		orig := make([]int, n)
+		for i := range orig {
+			orig[i] = r.Intn(10)
+		}
+		slice := append([]int{}, orig...)
+
+		Reverse(slice)
+		revPerm := Step(n-1, -1, -1)
+		applied := append([]int{}, orig...)
+		assert.NoError(t, ApplyPermutation(applied, revPerm))
+		assert.Equal(t, slice, applied)
+		Reverse(slice)
+		assert.Equal(t, orig, slice)
+
+		for k := -n - 1; k <= n+1; k++ {
+			Rotate(slice, k)
+			if n > 0 {
+				rotPerm := Conv(Step(0, n, 1), func(i int) (int, bool) {
+					return ((i+k)%n + n) % n, false
+				})
+				applied = append(applied[:0], orig...)
+				assert.NoError(t, ApplyPermutation(applied, rotPerm))
+				assert.Equal(t, applied, slice)
+			}
+			Rotate(slice, -k)
+			assert.Equal(t, orig, slice)
+		}
+
+		perm := Step(0, n, 1)
+		ShuffleT(perm, Seed[RandOpt](int64(n)))
+		inv, err := InversePermutation(perm)
+		assert.NoError(t, err)
+		assert.NoError(t, ApplyPermutation(slice, perm))
+		assert.NoError(t, ApplyPermutation(slice, inv))
+		assert.Equal(t, orig, slice)
+
+		// after wraparound, PrevPermutation also wraps back to orig
+		NextPermutation(slice)
+		PrevPermutation(slice)
+		assert.Equal(t, orig, slice)
+	}
+
+	last := []int{3, 2, 2, 1}
+	slice := append([]int{}, last...)
+	assert.False(t, NextPermutation(slice))
+	assert.Equal(t, []int{1, 2, 2, 3}, slice)
+	assert.False(t, PrevPermutation(slice))
+	assert.Equal(t, last, slice)
+
+	perms := map[[4]int]bool{}
+	slice = []int{1, 2, 3, 4}
+	for ok := true; ok; ok = NextPermutation(slice) {
+		perms[*(*[4]int)(slice)] = true
+	}
+	assert.Equal(t, 24, len(perms))
+	assert.Equal(t, []int{1, 2, 3, 4}, slice)
+	assert.False(t, PrevPermutation(slice))
+	assert.Equal(t, []int{4, 3, 2, 1}, slice)
+
+	assert.Equal(t, ERR_INVALID_INDEX, ApplyPermutation(slice, []int{0, 1, 1, 2}))
+	assert.Equal(t, ERR_INVALID_INDEX, ApplyPermutation(slice, []int{0, 1}))
+	_, err := InversePermutation([]int{0, 5})
+	assert.Equal(t, ERR_INVALID_INDEX, err)
 }
 
 func TestIntersect(t *testing.T) {
@@ -417,6 +997,117 @@ func TestIntersect(t *testing.T) {
 	assert.Equal(t, []int{2, 6, 4}, result)
 }
 
+func TestBag(t *testing.T) {
+	a, b := []string{"a", "a", "b"}, []string{"a", "b", "b"}
+
+	assert.Equal(t, []string{"a", "b"}, Intersect(a, b, Bag[IntersectOpt](true)))
+	assert.Equal(t, []string{"a", "b", "b"}, Intersect(a, b))
+	assert.Equal(t, []string{"a", "b"}, Sub(a, []string{"a"}, Bag[SubOpt](true)))
+	assert.Equal(t, []string{"b"}, Sub(a, []string{"a"}))
+
+	assert.Equal(t, []string{"a", "b"}, Union(a, b))
+	assert.Equal(t, []string{"a", "a", "b", "b"}, Union(a, b, Bag[SetOpt](true)))
+	assert.Equal(t, []string{"a", "b", "c"}, Union(a, []string{"c", "c"}))
+
+	assert.Equal(t, []string{}, SymmetricDifference(a, b))
+	assert.Equal(t, []string{"a", "b"}, SymmetricDifference(a, b, Bag[SetOpt](true)))
+	assert.Equal(t, []string{"b", "c"}, SymmetricDifference(a, []string{"a", "c", "c"}))
+	assert.Equal(t, []string{"a", "b", "c", "c"},
+		SymmetricDifference(a, []string{"a", "c", "c"}, Bag[SetOpt](true)))
+}
+
+type GameObject struct {
+	ID   int
+	Name string
+	Pos  []float64
+}
+
+func TestIntersectBy(t *testing.T) {
+	left := []GameObject{{1, "a", nil}, {2, "b", nil}, {3, "c", []float64{1}}, {2, "b2", nil}}
+	right := []GameObject{{3, "C", nil}, {4, "D", nil}, {2, "B", nil}, {4, "D2", nil}}
+	id := func(o *GameObject) int { return o.ID }
+	names := func(objs []GameObject) []string {
+		return Conv(objs, func(o GameObject) (string, bool) { return o.Name, false })
+	}
+
+	assert.Equal(t, []string{"b", "c", "b2"}, names(IntersectBy(left, right, id)))
+	assert.Equal(t, []string{"B", "C", "B"}, names(IntersectBy(left, right, id, RightWin[ByOpt](true))))
+	assert.Equal(t, []string{"a"}, names(SubBy(left, right, id)))
+	assert.Equal(t, []string{"D", "D2"}, names(SubBy(right, left, id)))
+	assert.Equal(t, []string{"a", "b", "c", "b2", "D"}, names(UnionBy(left, right, id)))
+	assert.Equal(t, []string{"a", "B", "C", "B", "D"}, names(UnionBy(left, right, id, RightWin[ByOpt](true))))
+	assert.Equal(t, []string{"C", "D", "B", "D2", "a"}, names(UnionBy(right, left, id)))
+}
+
+func TestGroupBy(t *testing.T) {
+	objs := []Element{{3, "a"}, {1, "b"}, {3, "c"}, {2, "d"}, {1, "e"}}
+	id := func(e *Element) int { return e.ID }
+
+	groups := GroupBy(objs, id)
+	assert.Equal(t, []Group[int, Element]{
+		{3, []Element{{3, "a"}, {3, "c"}}},
+		{1, []Element{{1, "b"}, {1, "e"}}},
+		{2, []Element{{2, "d"}}},
+	}, groups)
+
+	assert.Equal(t, []KeyCount[int]{{3, 2}, {1, 2}, {2, 1}}, CountBy(objs, id))
+
+	reused := GroupByInto(objs[:2], id, groups)
+	assert.Equal(t, []Group[int, Element]{
+		{3, []Element{{3, "a"}}},
+		{1, []Element{{1, "b"}}},
+	}, reused)
+	assert.Equal(t, &groups[0], &reused[0])
+
+	sort.Slice(objs, func(i, j int) bool { return objs[i].ID < objs[j].ID })
+	sorted := GroupBySorted(objs, func(s []Element, i int) int { return s[i].ID })
+	assert.Equal(t, 3, len(sorted))
+	assert.Equal(t, []int{1, 2, 3}, []int{sorted[0].Key, sorted[1].Key, sorted[2].Key})
+	assert.Equal(t, 2, len(sorted[2].Values))
+	assert.Equal(t, 0, len(GroupBySorted([]int{}, func(s []int, i int) int { return s[i] })))
+	assert.Equal(t, 0, len(GroupBy([]int{}, func(v *int) int { return *v })))
+}
+
+type Player struct {
+	ID   int
+	Name string
+}
+
+type Score struct {
+	PlayerID int
+	Point    int
+}
+
+func TestJoin(t *testing.T) {
+	players := []Player{{1, "alice"}, {2, "bob"}, {3, "carol"}}
+	scores := []Score{{3, 30}, {1, 10}, {3, 31}, {4, 40}}
+	pID := func(p *Player) int { return p.ID }
+	sID := func(s *Score) int { return s.PlayerID }
+	combine := func(p *Player, s *Score) string {
+		if s == nil {
+			return p.Name + ":-"
+		}
+		return fmt.Sprintf("%s:%d", p.Name, s.Point)
+	}
+
+	assert.Equal(t, []string{"alice:10", "carol:30", "carol:31"},
+		InnerJoin(players, scores, pID, sID, combine))
+	assert.Equal(t, []string{"alice:10", "bob:-", "carol:30", "carol:31"},
+		LeftJoin(players, scores, pID, sID, combine))
+	assert.Equal(t, []Player{{1, "alice"}, {3, "carol"}}, SemiJoin(players, scores, pID, sID))
+	assert.Equal(t, []Player{{2, "bob"}}, AntiJoin(players, scores, pID, sID))
+
+	sort.SliceStable(scores, func(i, j int) bool { return scores[i].PlayerID < scores[j].PlayerID })
+	pIdent := func(s []Player, i int) int { return s[i].ID }
+	sIdent := func(s []Score, i int) int { return s[i].PlayerID }
+	assert.Equal(t, InnerJoin(players, scores, pID, sID, combine),
+		InnerJoinSorted(players, scores, pIdent, sIdent, combine))
+	assert.Equal(t, LeftJoin(players, scores, pID, sID, combine),
+		LeftJoinSorted(players, scores, pIdent, sIdent, combine))
+	assert.Equal(t, []string{"bob:-"},
+		LeftJoinSorted(players[1:2], scores, pIdent, sIdent, combine))
+}
+
 func TestIntersectSSorted(t *testing.T) {
 	slice1 := []int{6, 4, 2, 1}
 	slice2 := []int{9, 6, 5, 3, 2}
@@ -454,6 +1145,40 @@ func TestSubSorted(t *testing.T) {
 	assert.Equal(t, []int{4, 10}, result)
 }
 
+func TestSortedSetAlgebra(t *testing.T) {
+	slice1 := []int{1, 2, 2, 4, 6, 6, 6}
+	slice2 := []int{2, 3, 4, 4, 6}
+	ident := func(s []int, i int) int { return s[i] }
+
+	assert.Equal(t, []int{1, 2, 2, 2, 3, 4, 4, 4, 6, 6, 6, 6},
+		UnionSorted(slice1, slice2, ident))
+	assert.Equal(t, []int{1, 2, 3, 4, 6},
+		UnionSorted(slice1, slice2, ident, Duplicate[SortedOpt](DupKeepFirst)))
+	assert.Equal(t, []int{1, 2, 2, 3, 4, 4, 6, 6, 6},
+		UnionSorted(slice1, slice2, ident, Duplicate[SortedOpt](DupKeepCount)))
+
+	assert.Equal(t, []int{1, 3},
+		SymmetricDiffSorted(slice1, slice2, ident))
+	assert.Equal(t, []int{1, 3},
+		SymmetricDiffSorted(slice1, slice2, ident, Duplicate[SortedOpt](DupKeepFirst)))
+	assert.Equal(t, []int{1, 2, 3, 4, 6, 6},
+		SymmetricDiffSorted(slice1, slice2, ident, Duplicate[SortedOpt](DupKeepCount)))
+
+	assert.True(t, IsSubsetSorted([]int{2, 2, 4}, slice1, ident))
+	assert.False(t, IsSubsetSorted([]int{2, 2, 4}, slice2, ident, Duplicate[SortedOpt](DupKeepCount)))
+	assert.True(t, IsSubsetSorted([]int{2, 2, 4}, slice2, ident))
+	assert.False(t, IsSubsetSorted([]int{2, 5}, slice2, ident))
+	assert.True(t, IsSubsetSorted([]int{}, slice2, ident))
+	assert.False(t, IsSubsetSorted([]int{1}, []int{}, ident))
+
+	objs1 := []Element{{ID: 1, Name: "a"}, {ID: 3, Name: "b"}}
+	objs2 := []Element{{ID: 1, Name: "c"}, {ID: 2, Name: "d"}}
+	assert.Equal(t, []Element{{ID: 1, Name: "a"}, {ID: 2, Name: "d"}, {ID: 3, Name: "b"}},
+		UnionSorted(objs1, objs2, func(s []Element, i int) int { return s[i].ID },
+			Duplicate[SortedOpt](DupKeepFirst)))
+	assert.Equal(t, []int{}, SymmetricDiffSorted([]int{}, []int{}, ident))
+}
+
 type V4sum struct {
 	A int
 }
@@ -512,6 +1237,65 @@ func TestInject(t *testing.T) {
 
 }
 
+func TestStats(t *testing.T) {
+	slice := []int{5, 1, 9, 3, 9, 1}
+
+	v, idx, err := Min(slice)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v)
+	assert.Equal(t, 1, idx)
+	v, idx, _ = Max(slice)
+	assert.Equal(t, 9, v)
+	assert.Equal(t, 2, idx)
+	_, _, err = Max([]int{})
+	assert.Equal(t, ERR_EMPTY_SLICE, err)
+
+	objs := []Element{{3, "c"}, {1, "z"}, {2, "a"}}
+	obj, idx, _ := MaxBy(objs, func(e *Element) string { return e.Name })
+	assert.Equal(t, "z", obj.Name)
+	assert.Equal(t, 1, idx)
+	obj, _, _ = MinBy(objs, func(e *Element) int { return e.ID })
+	assert.Equal(t, 1, obj.ID)
+
+	mean, _ := Mean(slice)
+	assert.InDelta(t, 14.0/3, mean, 1e-9)
+	variance, _ := Variance([]float64{2, 4, 4, 4, 5, 5, 7, 9})
+	assert.InDelta(t, 4.0, variance, 1e-9)
+	stddev, _ := Stddev([]float64{2, 4, 4, 4, 5, 5, 7, 9}, Unbiased[StatsOpt](true))
+	assert.InDelta(t, math.Sqrt(32.0/7), stddev, 1e-9)
+	_, err = Variance([]int{1}, Unbiased[StatsOpt](true))
+	assert.Equal(t, ERR_EMPTY_SLICE, err)
+
+	// large offset keeps precision
+	variance, _ = Variance([]float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16})
+	assert.InDelta(t, 22.5, variance, 1e-6)
+
+	median, _ := Median(slice)
+	assert.Equal(t, 4.0, median)
+	assert.Equal(t, []int{5, 1, 9, 3, 9, 1}, slice)
+
+	r := rand.New(rand.NewSource(1))
+	for n := 1; n < 50; n++ {
+		data := make([]float64, n)
+		for i := range data {
+			data[i] = float64(r.Intn(20))
+		}
+		sorted := Sort(append([]float64{}, data...))
+		for _, p := range []float64{0, 10, 25, 50, 90, 99, 100} {
+			rank := p / 100 * float64(n-1)
+			lo, hi := int(math.Floor(rank)), int(math.Ceil(rank))
+			expect := sorted[lo] + (sorted[hi]-sorted[lo])*(rank-float64(lo))
+			got, err := Percentile(data, p)
+			assert.NoError(t, err)
+			assert.InDelta(t, expect, got, 1e-9)
+		}
+	}
+	_, err = Percentile(slice, 101)
+	assert.Equal(t, ERR_INVALID_INDEX, err)
+	_, err = Median([]int{})
+	assert.Equal(t, ERR_EMPTY_SLICE, err)
+}
+
 func TestGettable(t *testing.T) {
 
 	slice1 := []int{10, 6, 4, 2}
@@ -534,6 +1318,152 @@ func TestConv(t *testing.T) {
 
 }
 
+func TestSeq(t *testing.T) {
+	slice1 := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	isEven := func(v *int) bool { return *v%2 == 0 }
+
+	result := SeqConv(
+		SeqOf(slice1).Filter(isEven).Skip(1).Take(3),
+		func(i int) (int64, bool) {
+			return int64(i * 10), false
+		}).ToSlice()
+	assert.Equal(t, []int64{40, 60, 80}, result)
+
+	sum := SeqInject(
+		SeqOf(slice1).Pipe(Filterable(isEven)),
+		InjectFn[int, int](func(r, e int) int { return r + e }),
+		Default(100))
+	assert.Equal(t, 130, sum)
+
+	assert.Equal(t, 5, SeqOf(slice1).Pipe(Deletable(isEven)).Count())
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, slice1)
+
+	called := 0
+	v, err := SeqOf(slice1).Filter(func(v *int) bool {
+		called++
+		return *v > 2
+	}).First()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, v)
+	assert.Equal(t, 3, called)
+
+	_, err = SeqOf(slice1).Take(0).First()
+	assert.Equal(t, ERR_NOT_FOUND, err)
+	assert.Equal(t, []int{}, SeqOf(slice1).Skip(20).ToSlice())
+	assert.Equal(t, 55, SeqOf(slice1).Inject(func(r, e int) int { return r + e }))
+}
+
+func TestParallel(t *testing.T) {
+	slice := MakeSliceSample()
+	expect := make([]Element, len(slice))
+	copy(expect, slice)
+
+	isEven := func(e *Element) bool { return e.ID%2 == 0 }
+	expect = Filterable(isEven)(expect)
+
+	selected := ParallelSelect(slice, isEven, Workers[ParallelOpt](4), ChunkSize[ParallelOpt](100))
+	assert.Equal(t, expect, selected)
+	assert.Equal(t, CREATE_SLICE_MAX, len(slice))
+
+	ids := ParallelConv(slice, func(e Element) (int, bool) {
+		return e.ID, e.ID%2 != 0
+	}, Workers[ParallelOpt](3))
+	assert.Equal(t, len(expect), len(ids))
+	for i := range expect {
+		assert.Equal(t, expect[i].ID, ids[i])
+	}
+
+	sum := ParallelInject(slice,
+		func(r int, e Element) int { return r + e.ID },
+		func(a, b int) int { return a + b },
+		Workers[ParallelOpt](8), ChunkSize[ParallelOpt](33))
+	assert.Equal(t, SumWithFn(slice, func(e Element) int { return e.ID }), sum)
+
+	filtered := ParallelFilter(slice, isEven, ChunkSize[ParallelOpt](7))
+	assert.Equal(t, expect, filtered)
+	for _, e := range slice[len(filtered):] {
+		assert.Equal(t, Element{}, e)
+	}
+
+	assert.Equal(t, 0, len(ParallelFilter([]int{}, has(1))))
+	assert.Equal(t, []int{3}, ParallelFilter([]int{1, 2, 3}, has(3), Workers[ParallelOpt](1)))
+}
+
+func TestStep(t *testing.T) {
+
+	assert.Equal(t, []int{0, 2, 4, 6, 8}, Step(0, 10, 2))
+	assert.Equal(t, []int{0, 2, 4, 6, 8, 10}, Step(0, 10, 2, Inclusive[StepOpt](true)))
+	assert.Equal(t, []int{10, 7, 4, 1}, Step(10, 0, -3))
+	assert.Equal(t, []int{3, 2, 1, 0}, Step(3, 0, -1, Inclusive[StepOpt](true)))
+	assert.Equal(t, 0, len(Step(0, 10, -1)))
+	assert.Equal(t, 0, len(Step[uint](10, 0, 1)))
+	assert.Nil(t, Step(0, 10, 0))
+
+	floats := Step(0.0, 1.0, 0.1, Inclusive[StepOpt](true))
+	assert.Equal(t, 11, len(floats))
+	assert.Equal(t, 0.3, math.Round(floats[3]*10)/10)
+	assert.Equal(t, 10, len(Step(0.0, 1.0, 0.1)))
+	floats = Step(0.0, 0.3, 0.1, Inclusive[StepOpt](true))
+	assert.Equal(t, 4, len(floats))
+	assert.Equal(t, 0.3, floats[3])
+	assert.Equal(t, 3, len(Step(0.0, 0.3, 0.1)))
+	floats32 := Step[float32](0.3, 0, -0.1, Inclusive[StepOpt](true))
+	assert.Equal(t, 4, len(floats32))
+	assert.Equal(t, float32(0), floats32[3])
+	assert.Equal(t, 0, len(Step(0.0, 0.0, 0.1)))
+	assert.Equal(t, []float64{0}, Step(0.0, 0.0, 0.1, Inclusive[StepOpt](true)))
+
+	// edges of integer types
+	uint8s := Step[uint8](0, 255, 1, Inclusive[StepOpt](true))
+	assert.Equal(t, 256, len(uint8s))
+	assert.Equal(t, uint8(255), uint8s[255])
+	assert.Equal(t, []uint8{250}, Step[uint8](250, 255, 10))
+	assert.Equal(t, []uint8{250}, Step[uint8](250, 255, 10, Inclusive[StepOpt](true)))
+	assert.Equal(t, 200, len(Step[int8](-100, 100, 1)))
+	assert.Equal(t, 256, len(Step[int8](127, -128, -1, Inclusive[StepOpt](true))))
+	assert.Equal(t, []int8{-128, -1}, Step[int8](-128, 127, 127, Inclusive[StepOpt](false))[:2])
+	assert.Equal(t, []int8{127, 0, -127}, Step[int8](127, -128, -127))
+	assert.Equal(t, []uint64{math.MaxUint64 - 1, math.MaxUint64}, Step[uint64](math.MaxUint64-1, math.MaxUint64, 1, Inclusive[StepOpt](true)))
+	assert.Equal(t, []int64{math.MinInt64, -1, math.MaxInt64 - 1}, Step[int64](math.MinInt64, math.MaxInt64, math.MaxInt64))
+
+	assert.Equal(t, 1000000, StepSeq(0, 1000000, 1).Count())
+	assert.Equal(t, []int{5, 6, 7}, StepSeq(0, math.MaxInt, 1).Skip(5).Take(3).ToSlice())
+
+	coords := Conv(Step(0, 3, 1), func(i int) ([2]int, bool) {
+		return [2]int{i % 2, i / 2}, false
+	})
+	assert.Equal(t, [][2]int{{0, 0}, {1, 0}, {0, 1}}, coords)
+	assert.Equal(t, 55, Sum(Step(1, 10, 1, Inclusive[StepOpt](true))))
+}
+
+func TestChunk(t *testing.T) {
+	slice := Step(0, 10, 1)
+
+	chunks := Chunk(slice, 4)
+	assert.Equal(t, [][]int{{0, 1, 2, 3}, {4, 5, 6, 7}, {8, 9}}, chunks)
+	chunks[0] = append(chunks[0], 100)
+	assert.Equal(t, 4, slice[4])
+	assert.Nil(t, Chunk(slice, 0))
+	assert.Equal(t, 0, len(Chunk([]int{}, 3)))
+
+	assert.Equal(t, [][]int{{0, 1, 2}, {3, 4, 5}, {6, 7, 8}}, Window(slice, 3, 3))
+	assert.Equal(t, [][]int{{0, 1, 2, 3, 4, 5, 6, 7}, {2, 3, 4, 5, 6, 7, 8, 9}}, Window(slice, 8, 2))
+	assert.Equal(t, 0, len(Window(slice, 11, 1)))
+
+	sums := Convertable(func(w []int) (int, bool) {
+		return Injectable(func(r, e int) int { return r + e })(w), false
+	})(Window(slice, 3, 1))
+	assert.Equal(t, []int{3, 6, 9, 12, 15, 18, 21, 24}, sums)
+
+	add := func(r, e int) int { return r + e }
+	sub := func(r, e int) int { return r - e }
+	assert.Equal(t, sums, SlidingInject(slice, 3, add, sub))
+	assert.Equal(t, sums, SlidingInject(slice, 3, add, nil))
+	assert.Equal(t, []int{103, 106}, SlidingInject(slice[:4], 3, add, sub, Default(100)))
+	assert.Equal(t, 0, len(SlidingInject(slice, 11, add, sub)))
+}
+
 func has[T comparable](a T) func(e *T) bool {
 	return func(e *T) bool {
 		return *e == a
@@ -608,6 +1538,127 @@ func TestMap(t *testing.T) {
 
 }
 
+func TestMapToolkit(t *testing.T) {
+
+	m := map[string]int{"b": 2, "a": 1, "c": 3, "d": 1}
+
+	assert.Equal(t, []string{"a", "b", "c", "d"}, Keys(m, KeyOrder[MapOpt[string, int]](Ascending[string])))
+	assert.Equal(t, []string{"d", "c", "b", "a"}, Keys(m, KeyOrder[MapOpt[string, int]](Descending[string])))
+	assert.Equal(t, []int{1, 2, 3, 1}, Values(m, KeyOrder[MapOpt[string, int]](Ascending[string])))
+	assert.Equal(t, []int{3, 2, 1, 1}, Values(m, ValueOrder[MapOpt[string, int]](Descending[int])))
+
+	strs := MapConv(m, func(k string, v int) (int, string, bool) {
+		return v * 10, k, k == "d"
+	})
+	assert.Equal(t, map[int]string{10: "a", 20: "b", 30: "c"}, strs)
+
+	odd, even := PartitionMap(m, func(k string, v int) bool { return v%2 == 1 })
+	assert.Equal(t, map[string]int{"a": 1, "c": 3, "d": 1}, odd)
+	assert.Equal(t, map[string]int{"b": 2}, even)
+	assert.Equal(t, odd, FilterMap(m, func(k string, v int) bool { return v%2 == 1 }))
+
+	merged := MergeMaps(nil, m, map[string]int{"a": 10, "e": 5})
+	assert.Equal(t, map[string]int{"a": 10, "b": 2, "c": 3, "d": 1, "e": 5}, merged)
+	merged = MergeMaps(func(k string, old, new int) int { return old + new }, m, map[string]int{"a": 10}, map[string]int{"a": 100})
+	assert.Equal(t, 111, merged["a"])
+	assert.Equal(t, 4, len(merged))
+
+	inv, collisions := InvertMap(m, func(a, b *string) bool { return *a < *b })
+	assert.Equal(t, 3, len(inv))
+	assert.Equal(t, "b", inv[2])
+	assert.Equal(t, "a", inv[1])
+	assert.Equal(t, 1, len(collisions))
+	assert.Equal(t, []string{"a", "d"}, collisions[1])
+
+	inv, collisions = InvertMap(m, func(a, b *string) bool { return *a > *b })
+	assert.Equal(t, "d", inv[1])
+	assert.Equal(t, []string{"d", "a"}, collisions[1])
+
+	_, collisions2 := InvertMap(map[int]int{1: 2, 2: 3}, func(a, b *int) bool { return *a < *b })
+	assert.Nil(t, collisions2)
+}
+
+func TestMapDiff(t *testing.T) {
+
+	old := map[string]int{"a": 1, "b": 2, "c": 3}
+	new := map[string]int{"b": 2, "c": 30, "d": 4}
+
+	d := MapDiff(old, new)
+	assert.Equal(t, map[string]int{"d": 4}, d.Added)
+	assert.Equal(t, map[string]int{"a": 1}, d.Removed)
+	assert.Equal(t, map[string]Change[int]{"c": {Old: 3, New: 30}}, d.Changed)
+	assert.False(t, d.Empty())
+	assert.True(t, MapDiff(new, new).Empty())
+
+	patched := MapPatch(old, d)
+	assert.Equal(t, new, patched)
+	assert.Equal(t, new, old)
+	assert.Equal(t, map[string]int{"c": 30, "d": 4}, MapPatch(nil, d))
+
+	objs1 := map[int][]float64{1: {1, 2}, 2: {3}}
+	objs2 := map[int][]float64{1: {1, 2}, 2: {3, 4}}
+	d2 := MapDiffFunc(objs1, objs2, func(a, b []float64) bool {
+		return fmt.Sprint(a) == fmt.Sprint(b)
+	})
+	assert.Equal(t, 0, len(d2.Added))
+	assert.Equal(t, 0, len(d2.Removed))
+	assert.Equal(t, []float64{3, 4}, d2.Changed[2].New)
+	assert.Equal(t, 1, len(d2.Changed))
+}
+
+func TestOrderedMap(t *testing.T) {
+
+	m := NewOrderedMap[string, int]()
+	_, _, found := m.Front()
+	assert.False(t, found)
+	assert.Equal(t, []string{}, m.Keys())
+
+	for i, k := range []string{"c", "a", "d", "b"} {
+		m.Set(k, i)
+	}
+	m.Set("a", 10)
+	assert.Equal(t, 4, m.Len())
+	assert.Equal(t, []string{"c", "a", "d", "b"}, m.Keys())
+	assert.Equal(t, []int{0, 10, 2, 3}, m.Values())
+
+	v, found := m.Get("a")
+	assert.True(t, found)
+	assert.Equal(t, 10, v)
+	_, found = m.Get("x")
+	assert.False(t, found)
+
+	assert.True(t, m.MoveToFront("d"))
+	assert.True(t, m.MoveToBack("c"))
+	assert.False(t, m.MoveToBack("x"))
+	assert.Equal(t, []string{"d", "a", "b", "c"}, m.Keys())
+
+	assert.True(t, m.Delete("a"))
+	assert.False(t, m.Delete("a"))
+	assert.Equal(t, 3, m.Len())
+	assert.Equal(t, []string{"d", "b", "c"}, m.Keys())
+
+	reversed := SeqConv(m.ReverseSeq(), func(p Pair[string, int]) (string, bool) {
+		return p.First, false
+	}).ToSlice()
+	assert.Equal(t, []string{"c", "b", "d"}, reversed)
+	assert.Equal(t, 2, m.Seq().Take(2).Count())
+
+	k, v, found := m.Front()
+	assert.Equal(t, "d", k)
+	assert.Equal(t, 2, v)
+	k, _, _ = m.Back()
+	assert.Equal(t, "c", k)
+
+	m.Delete("d")
+	m.Delete("b")
+	m.Delete("c")
+	assert.Equal(t, 0, m.Len())
+	_, _, found = m.Back()
+	assert.False(t, found)
+	m.Set("e", 5)
+	assert.Equal(t, []string{"e"}, m.Keys())
+}
+
 func TestZipper(t *testing.T) {
 
 	a := []string{"bob", "hoge", "one", "home"}
@@ -621,6 +1672,34 @@ func TestZipper(t *testing.T) {
 
 }
 
+func TestZip(t *testing.T) {
+
+	a := []string{"bob", "hoge", "one", "home"}
+	b := []int{0, 1, 2}
+
+	pairs, err := Zip(a, b)
+	assert.NoError(t, err)
+	assert.Equal(t, []Pair[string, int]{{"bob", 0}, {"hoge", 1}, {"one", 2}}, pairs)
+
+	_, err = Zip(a, b, Strict[ZipOpt](true))
+	assert.Equal(t, ERR_LENGTH_MISMATCH, err)
+
+	as, bs := Unzip(pairs)
+	assert.Equal(t, a[:3], as)
+	assert.Equal(t, b, bs)
+
+	triples, err := Zip3(a, b, []bool{true, false}, Strict[ZipOpt](false))
+	assert.NoError(t, err)
+	assert.Equal(t, []Triple[string, int, bool]{{"bob", 0, true}, {"hoge", 1, false}}, triples)
+	_, err = Zip3(a[:3], b, []bool{true}, Strict[ZipOpt](true))
+	assert.Equal(t, ERR_LENGTH_MISMATCH, err)
+
+	longest := ZipLongest(a, b, nil, Default(-1))
+	assert.Equal(t, Pair[string, int]{"home", -1}, longest[3])
+	longest = ZipLongest(a[:1], b, Default("none"), nil)
+	assert.Equal(t, []Pair[string, int]{{"bob", 0}, {"none", 1}, {"none", 2}}, longest)
+}
+
 // BenchmarkFilter/loncha.Filter-16         	     100	     89142 ns/op	   82119 B/op	       4 allocs/op
 // BenchmarkFilter/loncha.Filter_pointer-16 	     100	       201 ns/op	       0 B/op	       0 allocs/op
 // BenchmarkFilter/hand_Filter_pointer-16   	     100	     24432 ns/op	   81921 B/op	       1 allocs/op
@@ -771,6 +1850,45 @@ func BenchmarkFilter(b *testing.B) {
 	})
 }
 
+func BenchmarkFilterAdaptive(b *testing.B) {
+	orig := MakeSliceSample()
+	for i := range orig {
+		orig[i].ID = i
+	}
+
+	cases := []struct {
+		name string
+		cond CondFunc2[Element]
+	}{
+		{"sparse", func(e *Element) bool { return e.ID%1000 != 0 }},
+		{"dense", func(e *Element) bool { return e.ID%100 == 0 }},
+		{"half", func(e *Element) bool { return e.ID%2 == 0 }},
+		{"clustered", func(e *Element) bool { return e.ID < 2000 || e.ID >= 5000 }},
+		{"clustered10", func(e *Element) bool { return (e.ID/500)%2 == 0 }},
+	}
+
+	// FilterVersion(4) is not compared, because it drops the elements after the last run.
+	for _, c := range cases {
+		for _, v := range []struct {
+			name    string
+			version int
+		}{{"Filter2", 2}, {"Filter2.3", 3}, {"Filter2.adaptive", FILTER_VERSION_ADAPTIVE}} {
+			b.ResetTimer()
+			b.Run(c.name+"/loncha."+v.name, func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					objs := make([]Element, len(orig))
+					copy(objs, orig)
+					b.StartTimer()
+					Filter(objs, nil,
+						FilterVersion[FilterOpt[Element]](v.version),
+						Cond2[FilterOpt[Element]](c.cond))
+				}
+			})
+		}
+	}
+}
+
 // BenchmarkUniq/loncha.Uniq-16         	    			1000	    997543 ns/op	  548480 B/op	   16324 allocs/op
 // BenchmarkUniq/loncha.UniqWithSort-16 	    			1000	   2237924 ns/op	     256 B/op	       7 allocs/op
 // BenchmarkUniq/loncha.UniqWithSort(sort)-16         	    1000	    260283 ns/op	     144 B/op	       4 allocs/op
@@ -835,6 +1953,35 @@ func BenchmarkUniq(b *testing.B) {
 		}
 	})
 
+	b.ResetTimer()
+	b.Run("loncha.UniqInPlace", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			objs := make([]Element, len(orig))
+			copy(objs, orig)
+			b.StartTimer()
+			UniqInPlace(objs, func(e *Element) int {
+				return e.ID
+			})
+		}
+	})
+
+	b.ResetTimer()
+	b.Run("loncha.UniqSorted(before sort)", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			objs := make([]Element, len(orig))
+			copy(objs, orig)
+			sort.Slice(objs, func(i, j int) bool {
+				return objs[i].ID < objs[j].ID
+			})
+			b.StartTimer()
+			UniqSorted(objs, func(s []Element, i int) int {
+				return s[i].ID
+			})
+		}
+	})
+
 	b.ResetTimer()
 	b.Run("hand Uniq", func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
@@ -932,6 +2079,91 @@ func BenchmarkSelect(b *testing.B) {
 
 }
 
+func BenchmarkFind(b *testing.B) {
+	orig := MakeSliceSample()
+	id := orig[len(orig)-1].ID
+
+	b.ResetTimer()
+	b.Run("loncha.Find", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Find(&orig, func(i int) bool {
+				return orig[i].ID == id
+			})
+		}
+	})
+
+	b.ResetTimer()
+	b.Run("loncha.Find2", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Find2(orig, func(e *Element) bool {
+				return e.ID == id
+			})
+		}
+	})
+
+	b.ResetTimer()
+	b.Run("loncha.LastIndexOf", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			LastIndexOf(orig, func(i int) bool {
+				return orig[i].ID == -1
+			})
+		}
+	})
+
+	b.ResetTimer()
+	b.Run("loncha.LastIndexOf2", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			LastIndexOf2(orig, func(e *Element) bool {
+				return e.ID == -1
+			})
+		}
+	})
+
+	b.ResetTimer()
+	b.Run("loncha.Contain", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Contain(orig, func(i int) bool {
+				return orig[i].ID == -1
+			})
+		}
+	})
+
+	b.ResetTimer()
+	b.Run("loncha.Contain2", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Contain2(orig, func(e *Element) bool {
+				return e.ID == -1
+			})
+		}
+	})
+}
+
+func BenchmarkSeq(b *testing.B) {
+	orig := MakeSliceSample()
+
+	isEven := func(e *Element) bool { return e.ID%2 == 0 }
+	toID := func(e Element) (int, bool) { return e.ID, false }
+	sum := func(r, e int) int { return r + e }
+
+	b.ResetTimer()
+	b.Run("loncha.Filterable/Convertable/Injectable", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			objs := make([]Element, len(orig))
+			copy(objs, orig)
+			b.StartTimer()
+			Injectable(sum)(Convertable(toID)(Filterable(isEven)(objs)))
+		}
+	})
+
+	b.ResetTimer()
+	b.Run("loncha.Seq", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			SeqInject(SeqConv(SeqOf(orig).Filter(isEven), toID), sum)
+		}
+	})
+}
+
 type TestInterface interface {
 	Inc() int
 	Name() string
@@ -1,28 +1,121 @@
 package loncha
 
-func Keys[K comparable, V any](m map[K]V) (keys []K) {
+import "sort"
+
+// MapOpt ... functional option for Keys()/Values()
+type MapOpt[K comparable, V any] struct {
+	keyLess   LessFunc[K]
+	valueLess LessFunc[V]
+}
+
+func (mopt *MapOpt[K, V]) keyOrder(less LessFunc[K]) (prev LessFunc[K]) {
+	prev = mopt.keyLess
+	mopt.keyLess = less
+	return prev
+}
+
+func (mopt *MapOpt[K, V]) valueOrder(less LessFunc[V]) (prev LessFunc[V]) {
+	prev = mopt.valueLess
+	mopt.valueLess = less
+	return prev
+}
+
+type keyOrderSetter[T any, K any] interface {
+	keyOrder(LessFunc[K]) LessFunc[K]
+	*T
+}
+
+type valueOrderSetter[T any, V any] interface {
+	valueOrder(LessFunc[V]) LessFunc[V]
+	*T
+}
+
+// KeyOrder ... return Keys() sorted by less. Values() are also returned in order of keys.
+func KeyOrder[T any, K any, PT keyOrderSetter[T, K]](less LessFunc[K]) Opt[T] {
+	return func(p *opParam[T]) Opt[T] {
+		prev := PT(&p.Param).keyOrder(less)
+		return KeyOrder[T, K, PT](prev)
+	}
+}
+
+// ValueOrder ... return Values() sorted by less.
+func ValueOrder[T any, V any, PT valueOrderSetter[T, V]](less LessFunc[V]) Opt[T] {
+	return func(p *opParam[T]) Opt[T] {
+		prev := PT(&p.Param).valueOrder(less)
+		return ValueOrder[T, V, PT](prev)
+	}
+}
+
+// Ascending ... LessFunc of ascending order. use with KeyOrder()/ValueOrder()/Query.OrderBy()
+func Ascending[T Ordered](a, b *T) bool {
+	return *a < *b
+}
+
+// Descending ... LessFunc of descending order.
+func Descending[T Ordered](a, b *T) bool {
+	return *a > *b
+}
+
+func sortBy[T any](s []T, less LessFunc[T]) {
+	sort.Slice(s, func(i, j int) bool {
+		return less(&s[i], &s[j])
+	})
+}
+
+// Keys ... return keys of map. order is random without KeyOrder[MapOpt[K, V]]().
+func Keys[K comparable, V any](m map[K]V, opts ...Opt[MapOpt[K, V]]) (keys []K) {
 
 	if m == nil {
 		return nil
 	}
 
 	keys = make([]K, 0, len(m))
-	for k, _ := range m {
+	for k := range m {
 		keys = append(keys, k)
 	}
+	if len(opts) == 0 {
+		return
+	}
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	if param.Param.keyLess != nil {
+		sortBy(keys, param.Param.keyLess)
+	}
 	return
 }
 
-func Values[K comparable, V any](m map[K]V) (values []V) {
+// Values ... return values of map. order is random without ValueOrder[MapOpt[K, V]]() or KeyOrder[MapOpt[K, V]]().
+func Values[K comparable, V any](m map[K]V, opts ...Opt[MapOpt[K, V]]) (values []V) {
 
 	if m == nil {
 		return nil
 	}
 
 	values = make([]V, 0, len(m))
+	if len(opts) == 0 {
+		for _, v := range m {
+			values = append(values, v)
+		}
+		return
+	}
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	if param.Param.keyLess != nil && param.Param.valueLess == nil {
+		for _, k := range Keys(m, opts...) {
+			values = append(values, m[k])
+		}
+		return
+	}
 	for _, v := range m {
 		values = append(values, v)
 	}
+	if param.Param.valueLess != nil {
+		sortBy(values, param.Param.valueLess)
+	}
 	return
 }
 
@@ -39,3 +132,157 @@ func SelectMap[K comparable, V any](m map[K]V, fn func(k K, v V) (K, V, bool)) (
 	}
 	return
 }
+
+// MapConv ... convert map each key, value pair to other types. if fn returns true as removed, the pair is removed.
+func MapConv[K1 comparable, V1 any, K2 comparable, V2 any](m map[K1]V1, fn func(k K1, v V1) (K2, V2, bool)) (result map[K2]V2) {
+	result = make(map[K2]V2, len(m))
+	for k, v := range m {
+		nk, nv, removed := fn(k, v)
+		if removed {
+			continue
+		}
+		result[nk] = nv
+	}
+	return
+}
+
+// FilterMap ... return new map of pairs which fn returns true.
+func FilterMap[K comparable, V any](m map[K]V, fn func(k K, v V) bool) (result map[K]V) {
+	result, _ = PartitionMap(m, fn)
+	return
+}
+
+// PartitionMap ... split map into pairs which fn returns true and the rest.
+func PartitionMap[K comparable, V any](m map[K]V, fn func(k K, v V) bool) (matched, rest map[K]V) {
+	matched, rest = make(map[K]V), make(map[K]V)
+	for k, v := range m {
+		if fn(k, v) {
+			matched[k] = v
+			continue
+		}
+		rest[k] = v
+	}
+	return
+}
+
+// ConflictFunc ... resolve values of same key in MergeMaps(). old is merged value before, new is value of the next map.
+type ConflictFunc[K comparable, V any] func(k K, old, new V) V
+
+// MergeMaps ... merge maps into new map. if resolve is nil, value of the last map wins.
+func MergeMaps[K comparable, V any](resolve ConflictFunc[K, V], ms ...map[K]V) (result map[K]V) {
+
+	size := 0
+	for _, m := range ms {
+		size = max(size, len(m))
+	}
+	result = make(map[K]V, size)
+
+	for _, m := range ms {
+		for k, v := range m {
+			if old, found := result[k]; found && resolve != nil {
+				v = resolve(k, old, v)
+			}
+			result[k] = v
+		}
+	}
+	return
+}
+
+// InvertMap ... swap keys and values. if some keys have same value, the smallest key by less is used
+// in inverted map, and the value is reported in collisions with all of these keys sorted by less.
+func InvertMap[K, V comparable](m map[K]V, less LessFunc[K]) (inverted map[V]K, collisions map[V][]K) {
+
+	inverted = make(map[V]K, len(m))
+	for k, v := range m {
+		prev, found := inverted[v]
+		if !found {
+			inverted[v] = k
+			continue
+		}
+		if collisions == nil {
+			collisions = map[V][]K{}
+		}
+		if len(collisions[v]) == 0 {
+			collisions[v] = append(collisions[v], prev)
+		}
+		collisions[v] = append(collisions[v], k)
+		if less(&k, &prev) {
+			inverted[v] = k
+		}
+	}
+	for _, keys := range collisions {
+		sortBy(keys, less)
+	}
+	return
+}
+
+// Change ... old and new value of changed key in Diff
+type Change[V any] struct {
+	Old V
+	New V
+}
+
+// Diff ... difference between two maps by MapDiff()
+type Diff[K comparable, V any] struct {
+	Added   map[K]V
+	Removed map[K]V
+	Changed map[K]Change[V]
+}
+
+// Empty ... return true if there is no difference.
+func (d *Diff[K, V]) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// MapDiff ... return added, removed and changed entries from old to new. values are compared by ==.
+func MapDiff[K, V comparable](old, new map[K]V) *Diff[K, V] {
+	return MapDiffFunc(old, new, func(a, b V) bool {
+		return a == b
+	})
+}
+
+// MapDiffFunc ... MapDiff with custom equality function. V doesn't need to be comparable.
+func MapDiffFunc[K comparable, V any](old, new map[K]V, equal func(a, b V) bool) *Diff[K, V] {
+
+	d := &Diff[K, V]{
+		Added:   map[K]V{},
+		Removed: map[K]V{},
+		Changed: map[K]Change[V]{},
+	}
+
+	for k, ov := range old {
+		nv, found := new[k]
+		if !found {
+			d.Removed[k] = ov
+			continue
+		}
+		if !equal(ov, nv) {
+			d.Changed[k] = Change[V]{Old: ov, New: nv}
+		}
+	}
+	for k, nv := range new {
+		if _, found := old[k]; !found {
+			d.Added[k] = nv
+		}
+	}
+	return d
+}
+
+// MapPatch ... apply diff to m. m is modified and returned. if m is nil, new map is made.
+// applying MapDiff(old, new) to old makes it equal to new.
+func MapPatch[K comparable, V any](m map[K]V, d *Diff[K, V]) map[K]V {
+
+	if m == nil {
+		m = make(map[K]V, len(d.Added)+len(d.Changed))
+	}
+	for k := range d.Removed {
+		delete(m, k)
+	}
+	for k, v := range d.Added {
+		m[k] = v
+	}
+	for k, c := range d.Changed {
+		m[k] = c.New
+	}
+	return m
+}
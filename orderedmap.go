@@ -0,0 +1,158 @@
+package loncha
+
+import (
+	"unsafe"
+
+	"github.com/kazu/loncha/list_head"
+)
+
+type orderedEntry[K comparable, V any] struct {
+	list_head.ListHead
+	key   K
+	value V
+}
+
+func entryOf[K comparable, V any](ptr *list_head.ListHead) *orderedEntry[K, V] {
+	var e orderedEntry[K, V]
+	return (*orderedEntry[K, V])(unsafe.Pointer(uintptr(unsafe.Pointer(ptr)) - unsafe.Offsetof(e.ListHead)))
+}
+
+// OrderedMap ... map which remembers insertion order. entries are linked by list_head.ListHead,
+// so Set/Get/Delete/MoveToFront/MoveToBack are O(1).
+// OrderedMap is not safe for concurrent use.
+type OrderedMap[K comparable, V any] struct {
+	entries map[K]*orderedEntry[K, V]
+	head    list_head.ListHead
+	tail    list_head.ListHead
+}
+
+// NewOrderedMap ... return empty OrderedMap
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+
+	m := &OrderedMap[K, V]{entries: map[K]*orderedEntry[K, V]{}}
+	m.head.Init()
+	m.tail.Init()
+	m.head.Add(&m.tail)
+	return m
+}
+
+// Len ... the number of entries
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// Set ... set value of key. new key is added to the back. existing key keeps the position.
+func (m *OrderedMap[K, V]) Set(k K, v V) {
+
+	if e, found := m.entries[k]; found {
+		e.value = v
+		return
+	}
+	e := &orderedEntry[K, V]{key: k, value: v}
+	e.Init()
+	m.tail.Prev().Add(&e.ListHead)
+	m.entries[k] = e
+}
+
+// Get ... return value of key. if not found, return false.
+func (m *OrderedMap[K, V]) Get(k K) (v V, found bool) {
+
+	e, found := m.entries[k]
+	if !found {
+		return v, false
+	}
+	return e.value, true
+}
+
+// Delete ... delete key. if not found, return false.
+func (m *OrderedMap[K, V]) Delete(k K) bool {
+
+	e, found := m.entries[k]
+	if !found {
+		return false
+	}
+	e.ListHead.Delete()
+	delete(m.entries, k)
+	return true
+}
+
+// MoveToFront ... move key to the front. if not found, return false.
+func (m *OrderedMap[K, V]) MoveToFront(k K) bool {
+
+	e, found := m.entries[k]
+	if !found {
+		return false
+	}
+	e.ListHead.Delete()
+	m.head.Add(&e.ListHead)
+	return true
+}
+
+// MoveToBack ... move key to the back. if not found, return false.
+func (m *OrderedMap[K, V]) MoveToBack(k K) bool {
+
+	e, found := m.entries[k]
+	if !found {
+		return false
+	}
+	e.ListHead.Delete()
+	m.tail.Prev().Add(&e.ListHead)
+	return true
+}
+
+// Front ... return the first entry. if empty, return false.
+func (m *OrderedMap[K, V]) Front() (k K, v V, found bool) {
+	return m.edge(m.head.Next())
+}
+
+// Back ... return the last entry. if empty, return false. use with MoveToFront() as LRU.
+func (m *OrderedMap[K, V]) Back() (k K, v V, found bool) {
+	return m.edge(m.tail.Prev())
+}
+
+func (m *OrderedMap[K, V]) edge(ptr *list_head.ListHead) (k K, v V, found bool) {
+
+	if ptr == &m.head || ptr == &m.tail {
+		return k, v, false
+	}
+	e := entryOf[K, V](ptr)
+	return e.key, e.value, true
+}
+
+// Seq ... return Seq of entries from front to back.
+func (m *OrderedMap[K, V]) Seq() Seq[Pair[K, V]] {
+	return func(yield func(Pair[K, V]) bool) {
+		for ptr := m.head.Next(); ptr != &m.tail; ptr = ptr.Next() {
+			e := entryOf[K, V](ptr)
+			if !yield(Pair[K, V]{First: e.key, Second: e.value}) {
+				return
+			}
+		}
+	}
+}
+
+// ReverseSeq ... return Seq of entries from back to front.
+func (m *OrderedMap[K, V]) ReverseSeq() Seq[Pair[K, V]] {
+	return func(yield func(Pair[K, V]) bool) {
+		for ptr := m.tail.Prev(); ptr != &m.head; ptr = ptr.Prev() {
+			e := entryOf[K, V](ptr)
+			if !yield(Pair[K, V]{First: e.key, Second: e.value}) {
+				return
+			}
+		}
+	}
+}
+
+// Keys ... return keys in order.
+func (m *OrderedMap[K, V]) Keys() []K {
+	return SeqConv(m.Seq(), func(p Pair[K, V]) (K, bool) {
+		return p.First, false
+	}).ToSlice()
+}
+
+// Values ... return values in order.
+func (m *OrderedMap[K, V]) Values() []V {
+	return SeqConv(m.Seq(), func(p Pair[K, V]) (V, bool) {
+		return p.Second, false
+	}).ToSlice()
+}
@@ -0,0 +1,179 @@
+package loncha
+
+import (
+	"runtime"
+	"sync"
+)
+
+// ParallelOpt ... functional option for ParallelFilter()/ParallelConv()/ParallelInject()/ParallelSelect()
+type ParallelOpt struct {
+	workers   int
+	chunkSize int
+}
+
+func (popt *ParallelOpt) worker(n int) (prev int) {
+	prev = popt.workers
+	popt.workers = n
+	return prev
+}
+
+func (popt *ParallelOpt) chunk(n int) (prev int) {
+	prev = popt.chunkSize
+	popt.chunkSize = n
+	return prev
+}
+
+type workerSetter[T any] interface {
+	worker(int) int
+	*T
+}
+
+type chunkSizeSetter[T any] interface {
+	chunk(int) int
+	*T
+}
+
+// Workers ... set the number of goroutines. default is runtime.GOMAXPROCS(0)
+func Workers[T any, PT workerSetter[T]](n int) Opt[T] {
+	return func(p *opParam[T]) Opt[T] {
+		prev := PT(&p.Param).worker(n)
+		return Workers[T, PT](prev)
+	}
+}
+
+// ChunkSize ... set the number of elements processed by a goroutine at once.
+// default splits slice evenly by workers.
+func ChunkSize[T any, PT chunkSizeSetter[T]](n int) Opt[T] {
+	return func(p *opParam[T]) Opt[T] {
+		prev := PT(&p.Param).chunk(n)
+		return ChunkSize[T, PT](prev)
+	}
+}
+
+// chunks ... return the number of chunk, chunk size and goroutines for length
+func (popt *ParallelOpt) chunks(length int) (cnt, size, workers int) {
+
+	workers = popt.workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	size = popt.chunkSize
+	if size <= 0 {
+		size = (length + workers - 1) / workers
+	}
+	if size <= 0 {
+		size = 1
+	}
+	cnt = (length + size - 1) / size
+	workers = min(workers, cnt)
+	return
+}
+
+// runChunks ... call fn with each chunk [start, end) on goroutines.
+func (popt *ParallelOpt) runChunks(length int, fn func(idx, start, end int)) {
+
+	cnt, size, workers := popt.chunks(length)
+	if workers <= 1 {
+		for i := 0; i < cnt; i++ {
+			fn(i, i*size, min((i+1)*size, length))
+		}
+		return
+	}
+
+	idxCh := make(chan int, cnt)
+	for i := 0; i < cnt; i++ {
+		idxCh <- i
+	}
+	close(idxCh)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range idxCh {
+				fn(i, i*size, min((i+1)*size, length))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// ParallelFilter ... parallel variant of Filter. slice is filtered in place and keeps original order.
+func ParallelFilter[T any](slice []T, condFn CondFunc2[T], opts ...Opt[ParallelOpt]) []T {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	cnt, _, _ := param.Param.chunks(len(slice))
+	starts := make([]int, cnt)
+	lens := make([]int, cnt)
+
+	param.Param.runChunks(len(slice), func(idx, start, end int) {
+		chunk := slice[start:end:end]
+		innerFilter2(&chunk, true, condFn)
+		starts[idx], lens[idx] = start, len(chunk)
+	})
+
+	pos := 0
+	for i := range starts {
+		if pos != starts[i] {
+			copy(slice[pos:], slice[starts[i]:starts[i]+lens[i]])
+		}
+		pos += lens[i]
+	}
+	return truncate(slice, pos)
+}
+
+// ParallelSelect ... parallel variant of Select. return new slice with elements matched condFn in original order.
+func ParallelSelect[T any](slice []T, condFn CondFunc2[T], opts ...Opt[ParallelOpt]) []T {
+
+	return ParallelConv(slice, func(t T) (T, bool) {
+		return t, !condFn(&t)
+	}, opts...)
+}
+
+// ParallelConv ... parallel variant of Conv. results keep original order.
+func ParallelConv[S, D any](srcs []S, convfn ConvFunc[S, D], opts ...Opt[ParallelOpt]) (dsts []D) {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	cnt, _, _ := param.Param.chunks(len(srcs))
+	results := make([][]D, cnt)
+
+	param.Param.runChunks(len(srcs), func(idx, start, end int) {
+		results[idx] = Convertable(convfn)(srcs[start:end])
+	})
+
+	total := 0
+	for _, r := range results {
+		total += len(r)
+	}
+	dsts = make([]D, 0, total)
+	for _, r := range results {
+		dsts = append(dsts, r...)
+	}
+	return
+}
+
+// ParallelInject ... parallel variant of Inject.
+// each chunk is injected from zero value of V, and chunk results are merged by combine in original order.
+// so injectFn and combine should be associative, and zero value of V should be identity of combine.
+func ParallelInject[T, V any](s []T, injectFn InjectFn[T, V], combine func(V, V) V, opts ...Opt[ParallelOpt]) (v V) {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	cnt, _, _ := param.Param.chunks(len(s))
+	results := make([]V, cnt)
+
+	param.Param.runChunks(len(s), func(idx, start, end int) {
+		results[idx] = Inject(s[start:end], injectFn)
+	})
+
+	for _, r := range results {
+		v = combine(v, r)
+	}
+	return
+}
@@ -0,0 +1,50 @@
+package loncha
+
+func matchAll[T any](t *T, fns ...CondFunc2[T]) bool {
+	for _, fn := range fns {
+		if !fn(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// Partition ... rearrange slice in place into elements matched all fns and the others.
+// both halves keep relative order. rest elements are buffered once.
+func Partition[T any](s []T, fns ...CondFunc2[T]) (matched, rest []T) {
+
+	n := 0
+	var buf []T
+	for i := range s {
+		if matchAll(&s[i], fns...) {
+			s[n] = s[i]
+			n++
+			continue
+		}
+		buf = append(buf, s[i])
+	}
+	copy(s[n:], buf)
+
+	return s[:n:n], s[n:]
+}
+
+// PartitionUnstable ... fast variant of Partition without buffer. relative order is not kept.
+func PartitionUnstable[T any](s []T, fns ...CondFunc2[T]) (matched, rest []T) {
+
+	i, j := 0, len(s)-1
+	for {
+		for i <= j && matchAll(&s[i], fns...) {
+			i++
+		}
+		for i < j && !matchAll(&s[j], fns...) {
+			j--
+		}
+		if i >= j {
+			break
+		}
+		s[i], s[j] = s[j], s[i]
+		i++
+		j--
+	}
+	return s[:i:i], s[i:]
+}
@@ -0,0 +1,120 @@
+package loncha
+
+// Reverse ... Transforms an array such that the first element will become the last, the second element will become the second to last, etc.
+// for slice of interface{}, use OldReverse().
+func Reverse[T any](slice []T) {
+
+	for i, j := 0, len(slice)-1; i < j; i, j = i+1, j-1 {
+		slice[i], slice[j] = slice[j], slice[i]
+	}
+}
+
+// Rotate ... rotate slice to the left by k in place. slice[k] will become the first.
+// negative k rotates to the right.
+func Rotate[T any](slice []T, k int) {
+
+	length := len(slice)
+	if length == 0 {
+		return
+	}
+	k %= length
+	if k < 0 {
+		k += length
+	}
+	if k == 0 {
+		return
+	}
+	Reverse(slice[:k])
+	Reverse(slice[k:])
+	Reverse(slice)
+}
+
+// isPermutation ... return true if perm is permutation of 0..n-1
+func isPermutation(perm []int, n int) bool {
+
+	if len(perm) != n {
+		return false
+	}
+	seen := make([]bool, n)
+	for _, p := range perm {
+		if p < 0 || p >= n || seen[p] {
+			return false
+		}
+		seen[p] = true
+	}
+	return true
+}
+
+// ApplyPermutation ... rearrange slice in place so that new slice[i] is old slice[perm[i]].
+// return ERR_INVALID_INDEX if perm is not permutation of indexes of slice.
+func ApplyPermutation[T any](slice []T, perm []int) error {
+
+	if !isPermutation(perm, len(slice)) {
+		return ERR_INVALID_INDEX
+	}
+
+	done := make([]bool, len(slice))
+	for i := range slice {
+		if done[i] {
+			continue
+		}
+		tmp := slice[i]
+		j := i
+		for {
+			done[j] = true
+			k := perm[j]
+			if k == i {
+				slice[j] = tmp
+				break
+			}
+			slice[j] = slice[k]
+			j = k
+		}
+	}
+	return nil
+}
+
+// InversePermutation ... return inverse of perm. applying perm and its inverse keeps original order.
+// return ERR_INVALID_INDEX if perm is not permutation.
+func InversePermutation(perm []int) (inv []int, err error) {
+
+	if !isPermutation(perm, len(perm)) {
+		return nil, ERR_INVALID_INDEX
+	}
+	inv = make([]int, len(perm))
+	for i, p := range perm {
+		inv[p] = i
+	}
+	return inv, nil
+}
+
+// NextPermutation ... rearrange slice into the next lexicographically greater permutation.
+// if slice is the last permutation, rearrange into the first(sorted) and return false.
+func NextPermutation[T Ordered](slice []T) bool {
+	return nextPermutation(slice, func(a, b T) bool { return a < b })
+}
+
+// PrevPermutation ... rearrange slice into the previous lexicographically smaller permutation.
+// if slice is the first permutation, rearrange into the last(reverse sorted) and return false.
+func PrevPermutation[T Ordered](slice []T) bool {
+	return nextPermutation(slice, func(a, b T) bool { return a > b })
+}
+
+func nextPermutation[T any](slice []T, less func(a, b T) bool) bool {
+
+	i := len(slice) - 2
+	for i >= 0 && !less(slice[i], slice[i+1]) {
+		i--
+	}
+	if i < 0 {
+		Reverse(slice)
+		return false
+	}
+	j := len(slice) - 1
+	for !less(slice[i], slice[j]) {
+		j--
+	}
+	slice[i], slice[j] = slice[j], slice[i]
+	Reverse(slice[i+1:])
+	return true
+}
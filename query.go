@@ -0,0 +1,135 @@
+package loncha
+
+import "sort"
+
+// LessFunc ... compare function for Query.OrderBy()
+type LessFunc[T any] func(a, b *T) bool
+
+// By ... generate LessFunc comparing with key of keyFn.
+func By[T any, V Ordered](keyFn func(*T) V) LessFunc[T] {
+	return func(a, b *T) bool {
+		return keyFn(a) < keyFn(b)
+	}
+}
+
+type orderBy[T any] struct {
+	less LessFunc[T]
+	desc bool
+}
+
+// Query ... sql like query builder over slice.
+// conditions are recorded and run in order of Where/GroupBy/Having/OrderBy/Offset/Limit/Select
+// when terminal operation (All, Seq, Count, First, QuerySelect) is called.
+// intermediate slice is only made by OrderBy and QueryGroupBy.
+type Query[T any] struct {
+	src    Seq[T]
+	wheres []CondFunc2[T]
+	orders []orderBy[T]
+	offset int
+	limit  int
+}
+
+// From ... generate Query of slice. slice is not modified.
+func From[T any](s []T) *Query[T] {
+	return fromSeq(SeqOf(s))
+}
+
+func fromSeq[T any](src Seq[T]) *Query[T] {
+	return &Query[T]{src: src, limit: -1}
+}
+
+// Where ... add conditions. elements which all fns are true are selected.
+func (q *Query[T]) Where(fns ...CondFunc2[T]) *Query[T] {
+	q.wheres = append(q.wheres, fns...)
+	return q
+}
+
+// Having ... alias of Where for Query generated by QueryGroupBy()
+func (q *Query[T]) Having(fns ...CondFunc2[T]) *Query[T] {
+	return q.Where(fns...)
+}
+
+// OrderBy ... add sort order. first OrderBy is primary, and the next is used when same order.
+// sort is stable.
+func (q *Query[T]) OrderBy(less LessFunc[T], desc bool) *Query[T] {
+	q.orders = append(q.orders, orderBy[T]{less: less, desc: desc})
+	return q
+}
+
+// Offset ... skip first n elements.
+func (q *Query[T]) Offset(n int) *Query[T] {
+	q.offset = n
+	return q
+}
+
+// Limit ... select at most n elements.
+func (q *Query[T]) Limit(n int) *Query[T] {
+	q.limit = n
+	return q
+}
+
+func (q *Query[T]) less(a, b *T) bool {
+	for _, o := range q.orders {
+		if o.less(a, b) {
+			return !o.desc
+		}
+		if o.less(b, a) {
+			return o.desc
+		}
+	}
+	return false
+}
+
+// Seq ... return lazy Seq running this query.
+func (q *Query[T]) Seq() Seq[T] {
+
+	seq := q.src
+	if len(q.wheres) > 0 {
+		seq = seq.Filter(q.wheres...)
+	}
+	if len(q.orders) > 0 {
+		unsorted := seq
+		seq = func(yield func(T) bool) {
+			sorted := unsorted.ToSlice()
+			sort.SliceStable(sorted, func(i, j int) bool {
+				return q.less(&sorted[i], &sorted[j])
+			})
+			SeqOf(sorted)(yield)
+		}
+	}
+	if q.offset > 0 {
+		seq = seq.Skip(q.offset)
+	}
+	if q.limit >= 0 {
+		seq = seq.Take(q.limit)
+	}
+	return seq
+}
+
+// All ... run query and return result as slice.
+func (q *Query[T]) All() []T {
+	return q.Seq().ToSlice()
+}
+
+// Count ... run query and return the number of result.
+func (q *Query[T]) Count() int {
+	return q.Seq().Count()
+}
+
+// First ... run query and return first result. if no result, return ERR_NOT_FOUND.
+func (q *Query[T]) First() (T, error) {
+	return q.Seq().First()
+}
+
+// QuerySelect ... run query and return result converted by fn.
+func QuerySelect[T, D any](q *Query[T], fn ConvFunc[T, D]) []D {
+	return SeqConv(q.Seq(), fn).ToSlice()
+}
+
+// QueryGroupBy ... generate Query of groups by keyFn from result of q.
+// use Having() to select groups.
+func QueryGroupBy[T any, K comparable](q *Query[T], keyFn func(*T) K) *Query[Group[K, T]] {
+	return fromSeq(func(yield func(Group[K, T]) bool) {
+		SeqOf(GroupBy(q.All(), keyFn))(yield)
+	})
+}
@@ -3,7 +3,6 @@ package loncha
 import (
 	"errors"
 	"reflect"
-	"sort"
 )
 
 var (
@@ -12,6 +11,10 @@ var (
 	ERR_NOT_FOUND            error = errors.New("data is not found")
 	ERR_ELEMENT_INVALID_TYPE error = errors.New("slice element is invalid type")
 	ERR_INVALID_INDEX        error = errors.New("invalid index")
+	ERR_EMPTY_SLICE          error = errors.New("slice is empty")
+	ERR_LENGTH_MISMATCH      error = errors.New("length of slices are different")
+	ERR_SKETCH_MISMATCH      error = errors.New("sketches have different parameters")
+	ERR_INVALID_WEIGHT       error = errors.New("weight must be non-negative and sum of weights must be positive")
 )
 
 type CondFunc func(idx int) bool
@@ -46,10 +49,13 @@ func sliceElm2Reflect(slice interface{}) (reflect.Value, error) {
 	return rv.Elem(), nil
 }
 
-// Reverse ... Transforms an array such that the first element will become the last, the second element will become the second to last, etc.
-func Reverse(slice interface{}) {
+// OldReverse ... Reverse for slice of interface{}. use Reverse() if element type is known.
+func OldReverse(slice interface{}) {
 
-	sort.Slice(slice, func(i, j int) bool { return i > j })
+	swap := reflect.Swapper(slice)
+	for i, j := 0, reflect.ValueOf(slice).Len()-1; i < j; i, j = i+1, j-1 {
+		swap(i, j)
+	}
 }
 
 // Select ... return all element on match of CondFunc
@@ -0,0 +1,147 @@
+package loncha
+
+// Seq ... lazy pipeline of slice operations.
+// stages are only recorded, and run in a single pass when terminal operation (ToSlice, Inject ...) is called.
+type Seq[T any] func(yield func(T) bool)
+
+// SeqOf ... generate Seq from slice. slice is not copied.
+func SeqOf[T any](s []T) Seq[T] {
+	return func(yield func(T) bool) {
+		for i := range s {
+			if !yield(s[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Filter ... add stage keeping elements which all fns are true.
+// fns receive a pointer of copied element, so modification is passed to next stages only.
+func (seq Seq[T]) Filter(fns ...CondFunc2[T]) Seq[T] {
+	return func(yield func(T) bool) {
+		cur := new(T)
+		seq(func(t T) bool {
+			*cur = t
+			for _, fn := range fns {
+				if !fn(cur) {
+					return true
+				}
+			}
+			return yield(*cur)
+		})
+	}
+}
+
+// Pipe ... add stage with FilterFunc generated by Filterable()/Deletable()/Selectable().
+// fn is called with each element, so fn must decide per element.
+func (seq Seq[T]) Pipe(fn FilterFunc[T]) Seq[T] {
+	return func(yield func(T) bool) {
+		buf := make([]T, 1)
+		seq(func(t T) bool {
+			buf = buf[:1]
+			buf[0] = t
+			for _, d := range fn(buf) {
+				if !yield(d) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+}
+
+// Take ... add stage passing only first n elements.
+func (seq Seq[T]) Take(n int) Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		cnt := 0
+		seq(func(t T) bool {
+			cnt++
+			if !yield(t) {
+				return false
+			}
+			return cnt < n
+		})
+	}
+}
+
+// Skip ... add stage dropping first n elements.
+func (seq Seq[T]) Skip(n int) Seq[T] {
+	return func(yield func(T) bool) {
+		cnt := 0
+		seq(func(t T) bool {
+			if cnt < n {
+				cnt++
+				return true
+			}
+			return yield(t)
+		})
+	}
+}
+
+// SeqConv ... add conversion stage with ConvFunc.
+func SeqConv[S, D any](seq Seq[S], fn ConvFunc[S, D]) Seq[D] {
+	return func(yield func(D) bool) {
+		seq(func(s S) bool {
+			d, removed := fn(s)
+			if removed {
+				return true
+			}
+			return yield(d)
+		})
+	}
+}
+
+// SeqInject ... run pipeline and return an object formed from operands via InjectFn.
+func SeqInject[T, V any](seq Seq[T], injectFn InjectFn[T, V], opts ...OptCurry[V]) (v V) {
+	if len(opts) > 0 {
+		p := NewOpt(opts...)
+		v = p.Default
+	}
+	seq(func(t T) bool {
+		v = injectFn(v, t)
+		return true
+	})
+	return
+}
+
+// Inject ... same type variant of SeqInject
+func (seq Seq[T]) Inject(injectFn InjectFn[T, T], opts ...OptCurry[T]) T {
+	return SeqInject(seq, injectFn, opts...)
+}
+
+// Each ... run pipeline and call fn with each element. stop if fn return false.
+func (seq Seq[T]) Each(fn func(T) bool) {
+	seq(fn)
+}
+
+// ToSlice ... run pipeline and return result as slice.
+func (seq Seq[T]) ToSlice() (dsts []T) {
+	dsts = []T{}
+	seq(func(t T) bool {
+		dsts = append(dsts, t)
+		return true
+	})
+	return
+}
+
+// Count ... run pipeline and return the number of elements.
+func (seq Seq[T]) Count() (cnt int) {
+	seq(func(T) bool {
+		cnt++
+		return true
+	})
+	return
+}
+
+// First ... run pipeline until first element. if no element, return ERR_NOT_FOUND.
+func (seq Seq[T]) First() (v T, err error) {
+	err = ERR_NOT_FOUND
+	seq(func(t T) bool {
+		v, err = t, nil
+		return false
+	})
+	return
+}
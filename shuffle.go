@@ -37,3 +37,127 @@ func Shuffle(slice interface{}, seeds ...int64) (e error) {
 	}
 	return
 }
+
+// RandOpt ... functional option for ShuffleT()/ShuffleN()/Sample()
+type RandOpt struct {
+	src   rand.Source
+	pSeed *int64
+}
+
+func (ropt *RandOpt) source(src rand.Source) (prev rand.Source) {
+	prev = ropt.src
+	ropt.src = src
+	return prev
+}
+
+func (ropt *RandOpt) seed(pSeed *int64) (prev *int64) {
+	prev = ropt.pSeed
+	ropt.pSeed = pSeed
+	return prev
+}
+
+// rand ... return *rand.Rand from option. default source is mt19937.
+func (ropt *RandOpt) rand() (r *rand.Rand) {
+
+	if ropt.src != nil {
+		r = rand.New(ropt.src)
+	} else {
+		r = rand.New(mt19937.New())
+	}
+	if ropt.pSeed != nil {
+		r.Seed(*ropt.pSeed)
+	}
+	return
+}
+
+type sourceSetter[T any] interface {
+	source(rand.Source) rand.Source
+	*T
+}
+
+type seedSetter[T any] interface {
+	seed(*int64) *int64
+	*T
+}
+
+// RandSource ... set rand.Source. default is mt19937 which is same as Shuffle()
+func RandSource[T any, PT sourceSetter[T]](src rand.Source) Opt[T] {
+	return func(p *opParam[T]) Opt[T] {
+		prev := PT(&p.Param).source(src)
+		return RandSource[T, PT](prev)
+	}
+}
+
+// Seed ... set seed of rand.Source. same seed replays same result.
+func Seed[T any, PT seedSetter[T]](seed int64) Opt[T] {
+	return seedPtr[T, PT](&seed)
+}
+
+func seedPtr[T any, PT seedSetter[T]](pSeed *int64) Opt[T] {
+	return func(p *opParam[T]) Opt[T] {
+		prev := PT(&p.Param).seed(pSeed)
+		return seedPtr[T, PT](prev)
+	}
+}
+
+// ShuffleT ... Shuffle implementation with type parameters.
+func ShuffleT[T any](slice []T, opts ...Opt[RandOpt]) {
+	ShuffleN(slice, len(slice), opts...)
+}
+
+// ShuffleN ... randomize only first k positions by partial Fisher–Yates.
+// slice[:k] become uniform random sample of slice.
+func ShuffleN[T any](slice []T, k int, opts ...Opt[RandOpt]) {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	length := len(slice)
+	k = min(k, length)
+	if k <= 0 {
+		return
+	}
+	randMt := param.Param.rand()
+
+	for i := 0; i < k; i++ {
+		r := i + randMt.Intn(length-i)
+		slice[r], slice[i] = slice[i], slice[r]
+	}
+}
+
+// Sample ... return k elements randomly chosen from slice. slice is not modified.
+func Sample[T any](slice []T, k int, opts ...Opt[RandOpt]) []T {
+
+	dup := make([]T, len(slice))
+	copy(dup, slice)
+	ShuffleN(dup, k, opts...)
+	return dup[:min(max(k, 0), len(dup))]
+}
+
+// SampleSeq ... return k elements randomly chosen from seq by reservoir sampling.
+// seq is consumed once, so the length of seq is not required.
+func SampleSeq[T any](seq Seq[T], k int, opts ...Opt[RandOpt]) (result []T) {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	if k <= 0 {
+		return []T{}
+	}
+	randMt := param.Param.rand()
+	result = make([]T, 0, k)
+
+	n := 0
+	seq(func(t T) bool {
+		n++
+		if len(result) < k {
+			result = append(result, t)
+			return true
+		}
+		if r := randMt.Intn(n); r < k {
+			result[r] = t
+		}
+		return true
+	})
+	return
+}
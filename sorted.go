@@ -0,0 +1,157 @@
+package loncha
+
+// DupPolicy ... how to treat elements of same key in UnionSorted()/SymmetricDiffSorted()/IsSubsetSorted()
+type DupPolicy int
+
+const (
+	// DupKeepAll ... keep all elements of same key.
+	DupKeepAll DupPolicy = iota
+	// DupKeepFirst ... keep first element of same key only, slice1 has priority.
+	DupKeepFirst
+	// DupKeepCount ... treat slices as multiset, compare the count of same key on each side.
+	DupKeepCount
+)
+
+// SortedOpt ... functional option for UnionSorted()/SymmetricDiffSorted()/IsSubsetSorted()
+type SortedOpt struct {
+	dupPolicy DupPolicy
+}
+
+func (sopt *SortedOpt) duplicate(p DupPolicy) (prev DupPolicy) {
+	prev = sopt.dupPolicy
+	sopt.dupPolicy = p
+	return prev
+}
+
+type dupPolicySetter[T any] interface {
+	duplicate(DupPolicy) DupPolicy
+	*T
+}
+
+// Duplicate ... set DupPolicy. default is DupKeepAll.
+func Duplicate[T any, PT dupPolicySetter[T]](p DupPolicy) Opt[T] {
+	return func(param *opParam[T]) Opt[T] {
+		prev := PT(&param.Param).duplicate(p)
+		return Duplicate[T, PT](prev)
+	}
+}
+
+// mergeSorted ... walk two sorted slices by runs of same key. fn receives runs of each side, one of them may be empty.
+func mergeSorted[T any, V Ordered](slice1, slice2 []T, IdentFn IdentFunc[T, V], fn func(run1, run2 []T) bool) {
+
+	runEnd := func(slice []T, start int) (end int) {
+		key := IdentFn(slice, start)
+		for end = start + 1; end < len(slice) && IdentFn(slice, end) == key; end++ {
+		}
+		return
+	}
+
+	i, j := 0, 0
+	for i < len(slice1) || j < len(slice2) {
+		var run1, run2 []T
+		switch {
+		case j >= len(slice2):
+			end := runEnd(slice1, i)
+			run1, i = slice1[i:end], end
+		case i >= len(slice1):
+			end := runEnd(slice2, j)
+			run2, j = slice2[j:end], end
+		default:
+			k1, k2 := IdentFn(slice1, i), IdentFn(slice2, j)
+			if k1 <= k2 {
+				end := runEnd(slice1, i)
+				run1, i = slice1[i:end], end
+			}
+			if k2 <= k1 {
+				end := runEnd(slice2, j)
+				run2, j = slice2[j:end], end
+			}
+		}
+		if !fn(run1, run2) {
+			return
+		}
+	}
+}
+
+// UnionSorted ... union between 2 sorted slice by merging.
+func UnionSorted[T any, V Ordered](slice1, slice2 []T, IdentFn IdentFunc[T, V], opts ...Opt[SortedOpt]) (result []T) {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	result = make([]T, 0, max(len(slice1), len(slice2)))
+	mergeSorted(slice1, slice2, IdentFn, func(run1, run2 []T) bool {
+		switch param.Param.dupPolicy {
+		case DupKeepFirst:
+			if len(run1) > 0 {
+				result = append(result, run1[0])
+			} else {
+				result = append(result, run2[0])
+			}
+		case DupKeepCount:
+			result = append(result, run1...)
+			if len(run2) > len(run1) {
+				result = append(result, run2[len(run1):]...)
+			}
+		default:
+			result = append(result, run1...)
+			result = append(result, run2...)
+		}
+		return true
+	})
+	return
+}
+
+// SymmetricDiffSorted ... elements which exist only in one of 2 sorted slice.
+// with DupKeepCount, the excess of the larger side is kept.
+func SymmetricDiffSorted[T any, V Ordered](slice1, slice2 []T, IdentFn IdentFunc[T, V], opts ...Opt[SortedOpt]) (result []T) {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	result = make([]T, 0)
+	mergeSorted(slice1, slice2, IdentFn, func(run1, run2 []T) bool {
+		switch param.Param.dupPolicy {
+		case DupKeepFirst:
+			if len(run1) == 0 {
+				result = append(result, run2[0])
+			} else if len(run2) == 0 {
+				result = append(result, run1[0])
+			}
+		case DupKeepCount:
+			if len(run1) > len(run2) {
+				result = append(result, run1[len(run2):]...)
+			} else {
+				result = append(result, run2[len(run1):]...)
+			}
+		default:
+			if len(run1) == 0 || len(run2) == 0 {
+				result = append(result, run1...)
+				result = append(result, run2...)
+			}
+		}
+		return true
+	})
+	return
+}
+
+// IsSubsetSorted ... return true if all keys of slice1 exist in slice2.
+// with DupKeepCount, the count of each key in slice1 must not exceed slice2.
+func IsSubsetSorted[T any, V Ordered](slice1, slice2 []T, IdentFn IdentFunc[T, V], opts ...Opt[SortedOpt]) (isSubset bool) {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	isSubset = true
+	mergeSorted(slice1, slice2, IdentFn, func(run1, run2 []T) bool {
+		if len(run1) == 0 {
+			return true
+		}
+		if len(run2) == 0 ||
+			(param.Param.dupPolicy == DupKeepCount && len(run1) > len(run2)) {
+			isSubset = false
+		}
+		return isSubset
+	})
+	return
+}
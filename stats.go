@@ -0,0 +1,174 @@
+package loncha
+
+import (
+	"math"
+)
+
+// StatsOpt ... functional option for Variance()/Stddev()
+type StatsOpt struct {
+	isUnbiased bool
+}
+
+func (sopt *StatsOpt) unbiased(v bool) (prev bool) {
+	prev = sopt.isUnbiased
+	sopt.isUnbiased = v
+	return prev
+}
+
+type unbiasedSetter[T any] interface {
+	unbiased(bool) bool
+	*T
+}
+
+// Unbiased ... use sample variance divided by n-1. default is population variance divided by n.
+func Unbiased[T any, PT unbiasedSetter[T]](v bool) Opt[T] {
+	return func(p *opParam[T]) Opt[T] {
+		prev := PT(&p.Param).unbiased(v)
+		return Unbiased[T, PT](prev)
+	}
+}
+
+// Min ... return minimum value and index of it. if slice is empty, return ERR_EMPTY_SLICE.
+func Min[T Number](s []T) (T, int, error) {
+	return MinBy(s, func(t *T) T { return *t })
+}
+
+// Max ... return maximum value and index of it. if slice is empty, return ERR_EMPTY_SLICE.
+func Max[T Number](s []T) (T, int, error) {
+	return MaxBy(s, func(t *T) T { return *t })
+}
+
+// MinBy ... return element which keyFn is minimum and index of it. first one is returned if same keys.
+func MinBy[T any, V Ordered](s []T, keyFn func(*T) V) (T, int, error) {
+	return winnerBy(s, keyFn, func(a, b V) bool { return a < b })
+}
+
+// MaxBy ... return element which keyFn is maximum and index of it. first one is returned if same keys.
+func MaxBy[T any, V Ordered](s []T, keyFn func(*T) V) (T, int, error) {
+	return winnerBy(s, keyFn, func(a, b V) bool { return a > b })
+}
+
+func winnerBy[T any, V Ordered](s []T, keyFn func(*T) V, better func(a, b V) bool) (t T, idx int, err error) {
+
+	if len(s) == 0 {
+		return t, -1, ERR_EMPTY_SLICE
+	}
+	best := keyFn(&s[0])
+	for i := 1; i < len(s); i++ {
+		if key := keyFn(&s[i]); better(key, best) {
+			best, idx = key, i
+		}
+	}
+	return s[idx], idx, nil
+}
+
+// Mean ... return arithmetic mean. if slice is empty, return ERR_EMPTY_SLICE.
+func Mean[T Number](s []T) (float64, error) {
+
+	if len(s) == 0 {
+		return 0, ERR_EMPTY_SLICE
+	}
+	mean, _ := welford(s)
+	return mean, nil
+}
+
+// welford ... return mean and sum of squares of differences from the mean by Welford's algorithm.
+func welford[T Number](s []T) (mean, m2 float64) {
+
+	for i, v := range s {
+		x := float64(v)
+		delta := x - mean
+		mean += delta / float64(i+1)
+		m2 += delta * (x - mean)
+	}
+	return
+}
+
+// Variance ... return population variance with numerically stable Welford's algorithm.
+// use Unbiased[StatsOpt](true) to get sample variance.
+func Variance[T Number](s []T, opts ...Opt[StatsOpt]) (float64, error) {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	n := len(s)
+	if param.Param.isUnbiased {
+		n--
+	}
+	if n <= 0 {
+		return 0, ERR_EMPTY_SLICE
+	}
+	_, m2 := welford(s)
+	return m2 / float64(n), nil
+}
+
+// Stddev ... return standard deviation. square root of Variance()
+func Stddev[T Number](s []T, opts ...Opt[StatsOpt]) (float64, error) {
+
+	v, err := Variance(s, opts...)
+	return math.Sqrt(v), err
+}
+
+// Median ... return median. slice is not modified.
+func Median[T Number](s []T) (float64, error) {
+	return Percentile(s, 50)
+}
+
+// Percentile ... return p-th percentile (0 <= p <= 100) with linear interpolation between closest ranks.
+// values are found by quickselect without sorting. slice is not modified.
+// return ERR_INVALID_INDEX if p is out of range.
+func Percentile[T Number](s []T, p float64) (float64, error) {
+
+	if len(s) == 0 {
+		return 0, ERR_EMPTY_SLICE
+	}
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return 0, ERR_INVALID_INDEX
+	}
+
+	dup := make([]T, len(s))
+	copy(dup, s)
+
+	rank := p / 100 * float64(len(dup)-1)
+	lo := int(math.Floor(rank))
+	quickSelect(dup, lo)
+	lower := float64(dup[lo])
+	if frac := rank - float64(lo); frac > 0 {
+		// after selecting, dup[lo+1:] are not less than dup[lo]
+		upper, _, _ := Min(dup[lo+1:])
+		return lower + (float64(upper)-lower)*frac, nil
+	}
+	return lower, nil
+}
+
+// quickSelect ... rearrange s so that s[k] is k-th smallest, s[:k] are not greater and s[k+1:] are not less.
+func quickSelect[T Number](s []T, k int) {
+
+	lo, hi := 0, len(s)-1
+	for lo < hi {
+		pivot := s[lo+(hi-lo)/2]
+		// 3-way partition: [lo,lt) < pivot, [lt,gt] == pivot, (gt,hi] > pivot
+		lt, i, gt := lo, lo, hi
+		for i <= gt {
+			switch {
+			case s[i] < pivot:
+				s[lt], s[i] = s[i], s[lt]
+				lt++
+				i++
+			case s[i] > pivot:
+				s[i], s[gt] = s[gt], s[i]
+				gt--
+			default:
+				i++
+			}
+		}
+		switch {
+		case k < lt:
+			hi = lt - 1
+		case k > gt:
+			lo = gt + 1
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package loncha
+
+import (
+	"math"
+	"unsafe"
+)
+
+// StepOpt ... functional option for Step()/StepSeq()
+type StepOpt struct {
+	isInclusive bool
+}
+
+func (sopt *StepOpt) inclusive(v bool) (prev bool) {
+	prev = sopt.isInclusive
+	sopt.isInclusive = v
+	return prev
+}
+
+type inclusiveSetter[T any] interface {
+	inclusive(bool) bool
+	*T
+}
+
+// Inclusive ... include end value in Step(). default is exclusive.
+func Inclusive[T any, PT inclusiveSetter[T]](v bool) Opt[T] {
+	return func(p *opParam[T]) Opt[T] {
+		prev := PT(&p.Param).inclusive(v)
+		return Inclusive[T, PT](prev)
+	}
+}
+
+// isFloat ... return true if T is float type.
+func isFloat[T Number]() bool {
+	half := 0.5
+	return T(half) != 0
+}
+
+// stepFloatTolerance ... ratio (end-start)/step within this ulps of integer is treated as integer.
+const stepFloatTolerance float64 = 8
+
+// stepLast ... return index of the last value from start to end by step. ok is false if there is no value.
+// integer span is computed in uint64 to avoid overflow of T.
+func stepLast[T Number](start, end, step T, inclusive bool) (last uint64, ok bool) {
+
+	if (step > 0 && start > end) || (step < 0 && start < end) {
+		return 0, false
+	}
+	if isFloat[T]() {
+		return stepLastFloat(float64(end-start)/float64(step), unsafe.Sizeof(step) == 4, inclusive)
+	}
+
+	// two's complement: difference and negation are correct in uint64 even if signed T is negative.
+	span, size := uint64(end)-uint64(start), uint64(step)
+	if step < 0 {
+		span, size = uint64(start)-uint64(end), -uint64(step)
+	}
+	if inclusive {
+		return span / size, true
+	}
+	if span == 0 {
+		return 0, false
+	}
+	return (span - 1) / size, true
+}
+
+// stepLastFloat ... stepLast for float. ratio is (end-start)/step.
+// if ratio is within stepFloatTolerance ulps of integer, end is treated as reached exactly.
+func stepLastFloat(ratio float64, is32, inclusive bool) (last uint64, ok bool) {
+
+	if math.IsNaN(ratio) || ratio < 0 {
+		return 0, false
+	}
+	eps := math.Pow(2, -52)
+	if is32 {
+		eps = math.Pow(2, -23)
+	}
+	if r := math.Round(ratio); math.Abs(ratio-r) <= stepFloatTolerance*eps*math.Max(1, r) {
+		ratio = r
+	}
+	if !inclusive {
+		if ratio == 0 {
+			return 0, false
+		}
+		ratio = math.Ceil(ratio) - 1
+	}
+	if ratio >= math.MaxUint64 {
+		return math.MaxUint64, true
+	}
+	return uint64(math.Floor(ratio)), true
+}
+
+// Step ... return numbers from start to end by step. step can be negative.
+// for float, i-th value is calculated as start + i*step, so float error is not accumulated.
+// if (end-start)/step is within a few ulps of integer, end is treated as reached exactly,
+// e.g. Step(0.0, 0.3, 0.1, Inclusive[StepOpt](true)) returns 4 values and the last is 0.3.
+// end is excluded by default, use Inclusive[StepOpt](true) to include it.
+// return nil if step is zero.
+func Step[T Number](start, end, step T, opts ...Opt[StepOpt]) (result []T) {
+
+	if step == 0 {
+		return nil
+	}
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	last, ok := stepLast(start, end, step, param.Param.isInclusive)
+	if ok && last < math.MaxInt32 {
+		result = make([]T, 0, int(last)+1)
+	}
+	StepSeq(start, end, step, opts...)(func(v T) bool {
+		result = append(result, v)
+		return true
+	})
+	return
+}
+
+// StepSeq ... lazy variant of Step(). values are generated without allocating slice.
+func StepSeq[T Number](start, end, step T, opts ...Opt[StepOpt]) Seq[T] {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+	inclusive := param.Param.isInclusive
+
+	return func(yield func(T) bool) {
+		if step == 0 {
+			return
+		}
+		last, ok := stepLast(start, end, step, inclusive)
+		if !ok {
+			return
+		}
+		isF := isFloat[T]()
+		v := start
+		for i := uint64(0); ; i++ {
+			if isF {
+				v = start + T(i)*step
+				if i == last && inclusive && (step > 0) == (v > end) {
+					// end is reached within tolerance
+					v = end
+				}
+			}
+			if !yield(v) || i == last {
+				return
+			}
+			if !isF {
+				v += step
+			}
+		}
+	}
+}
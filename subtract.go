@@ -3,6 +3,27 @@ package loncha
 import "sort"
 
 type subOpt struct {
+	isBag bool
+	ctxOpt
+}
+
+// SubOpt ... functional option for Sub()
+type SubOpt = subOpt
+
+func (sopt *subOpt) bag(v bool) (prev bool) {
+	prev = sopt.isBag
+	sopt.isBag = v
+	return prev
+}
+
+func mapOfCounts[T comparable](slice []T) (cnts map[T]int) {
+
+	cnts = map[T]int{}
+	for _, v := range slice {
+		cnts[v]++
+	}
+
+	return
 }
 
 func mapOfExists[T comparable](slice []T) (exists map[T]bool) {
@@ -15,15 +36,98 @@ func mapOfExists[T comparable](slice []T) (exists map[T]bool) {
 	return
 }
 
+// mapOfCountsCtx ... mapOfCounts checking context. return *PartialError if canceled.
+func mapOfCountsCtx[T comparable](slice []T, c *ctxOpt) (cnts map[T]int, err error) {
+
+	if c.ctx == nil {
+		return mapOfCounts(slice), nil
+	}
+	cnts = map[T]int{}
+	for i, v := range slice {
+		if err = c.canceled(i); err != nil {
+			return
+		}
+		cnts[v]++
+	}
+	return
+}
+
+// mapOfExistsCtx ... mapOfExists checking context. return *PartialError if canceled.
+func mapOfExistsCtx[T comparable](slice []T, c *ctxOpt) (exists map[T]bool, err error) {
+
+	if c.ctx == nil {
+		return mapOfExists(slice), nil
+	}
+	exists = map[T]bool{}
+	for i, v := range slice {
+		if err = c.canceled(i); err != nil {
+			return
+		}
+		exists[v] = true
+	}
+	return
+}
+
+// mapOfIndexBy ... same as mapOfExists, but with key function. value is index of first element of the key.
+func mapOfIndexBy[T any, K comparable](slice []T, keyFn func(*T) K) (exists map[K]int) {
+
+	exists = make(map[K]int, len(slice))
+	for i := range slice {
+		key := keyFn(&slice[i])
+		if _, found := exists[key]; !found {
+			exists[key] = i
+		}
+	}
+
+	return
+}
+
+// ByOpt ... functional option for IntersectBy()/SubBy()/UnionBy()
+type ByOpt struct {
+	isRightWin bool
+}
+
+func (bopt *ByOpt) rightWin(v bool) (prev bool) {
+	prev = bopt.isRightWin
+	bopt.isRightWin = v
+	return prev
+}
+
+type rightWinSetter[T any] interface {
+	rightWin(bool) bool
+	*T
+}
+
+// RightWin ... use element of right slice if both slices have same key. default is left.
+func RightWin[T any, PT rightWinSetter[T]](v bool) Opt[T] {
+	return func(p *opParam[T]) Opt[T] {
+		prev := PT(&p.Param).rightWin(v)
+		return RightWin[T, PT](prev)
+	}
+}
+
 // Sub .. subtraction between two slices.
+// with Bag[SubOpt](true), each element of slice2 removes only one same element of slice1.
+// with WithContext[SubOpt](), stop when context is done and return the result of processed elements of slice1.
+// slice2 is hashed before slice1, so PartialError.Processed counts elements of slice2 first.
 func Sub[T comparable](slice1, slice2 []T, opts ...Opt[subOpt]) (result []T) {
 
 	param, fn := MergeOpts(opts...)
 	defer fn(param)
 
-	exists := mapOfExists(slice2)
+	if param.Param.isBag {
+		return subBag(slice1, slice2, &param.Param.ctxOpt)
+	}
 
-	for _, v := range slice1 {
+	exists, err := mapOfExistsCtx(slice2, &param.Param.ctxOpt)
+	if err != nil {
+		return []T{}
+	}
+
+	for i, v := range slice1 {
+		if param.Param.ctx != nil && param.Param.canceled(len(slice2)+i) != nil {
+			return
+		}
 		if _, found := exists[v]; !found {
 			result = append(result, v)
 		}
@@ -32,6 +136,44 @@ func Sub[T comparable](slice1, slice2 []T, opts ...Opt[subOpt]) (result []T) {
 
 }
 
+func subBag[T comparable](slice1, slice2 []T, c *ctxOpt) (result []T) {
+
+	cnts, err := mapOfCountsCtx(slice2, c)
+	if err != nil {
+		return []T{}
+	}
+
+	for i, v := range slice1 {
+		if c.ctx != nil && c.canceled(len(slice2)+i) != nil {
+			return
+		}
+		if cnts[v] > 0 {
+			cnts[v]--
+			continue
+		}
+		result = append(result, v)
+	}
+	return
+}
+
+// SubBy ... subtraction between two slices by key of keyFn. T doesn't need to be comparable.
+// order of left is kept.
+func SubBy[T any, K comparable](left, right []T, keyFn func(*T) K, opts ...Opt[ByOpt]) (result []T) {
+
+	param, fn := MergeOpts(opts...)
+	defer fn(param)
+
+	exists := mapOfIndexBy(right, keyFn)
+	result = make([]T, 0, len(left))
+
+	for i := range left {
+		if _, found := exists[keyFn(&left[i])]; !found {
+			result = append(result, left[i])
+		}
+	}
+	return
+}
+
 // SubSorted ... subtraction in sorted slice
 func SubSorted[T any, V Ordered](slice1, slice2 []T, IdentFn IdentFunc[T, V]) (result []T) {
 
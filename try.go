@@ -0,0 +1,180 @@
+package loncha
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TryCondFunc ... condition function which can fail for TryFilter()/TrySelect()
+type TryCondFunc[T any] func(t *T) (bool, error)
+
+// TryConvFunc ... conversion function which can fail for TryConv()
+type TryConvFunc[S, D any] func(S) (D, bool, error)
+
+// TryInjectFn ... inject function which can fail for TryInject()
+type TryInjectFn[T, R any] func(R, T) (R, error)
+
+// ElementError ... error of the element at Index
+type ElementError struct {
+	Index int
+	Err   error
+}
+
+func (e *ElementError) Error() string {
+	return fmt.Sprintf("index %d: %s", e.Index, e.Err.Error())
+}
+
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
+
+// Errors ... joined errors collected with CollectErrors()
+type Errors []error
+
+func (errs Errors) Error() string {
+	msgs := make([]string, 0, len(errs))
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Is ... support errors.Is(). report whether any error matches target.
+func (errs Errors) Is(target error) bool {
+	for _, err := range errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As ... support errors.As(). find the first error which matches target.
+func (errs Errors) As(target interface{}) bool {
+	for _, err := range errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unwrap ... return each error.
+func (errs Errors) Unwrap() []error {
+	return errs
+}
+
+// TryOpt ... functional option for TryFilter()/TrySelect()/TryConv()/TryInject()
+type TryOpt struct {
+	isCollect bool
+}
+
+func (topt *TryOpt) collect(v bool) (prev bool) {
+	prev = topt.isCollect
+	topt.isCollect = v
+	return prev
+}
+
+type collectSetter[T any] interface {
+	collect(bool) bool
+	*T
+}
+
+// CollectErrors ... process all elements and return all errors as Errors. default stops at the first error.
+func CollectErrors[T any, PT collectSetter[T]](v bool) Opt[T] {
+	return func(p *opParam[T]) Opt[T] {
+		prev := PT(&p.Param).collect(v)
+		return CollectErrors[T, PT](prev)
+	}
+}
+
+// tryEach ... call fn with each index. stop at the first error or collect errors as *ElementError.
+func tryEach(length int, isCollect bool, fn func(i int) error) (stopped int, err error) {
+
+	var errs Errors
+	for i := 0; i < length; i++ {
+		e := fn(i)
+		if e == nil {
+			continue
+		}
+		if !isCollect {
+			return i, &ElementError{Index: i, Err: e}
+		}
+		errs = append(errs, &ElementError{Index: i, Err: e})
+	}
+	if len(errs) > 0 {
+		return length, errs
+	}
+	return length, nil
+}
+
+// TryFilter ... Filter with condition function which can fail. slice is filtered in place.
+// if error occurs, the element is kept.
+// by default, stop at the first error and return filtered elements before it followed by
+// the failed element and the rest not processed, in original order.
+func TryFilter[T any](slice []T, fn TryCondFunc[T], opts ...Opt[TryOpt]) ([]T, error) {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	n := 0
+	stopped, err := tryEach(len(slice), param.Param.isCollect, func(i int) error {
+		ok, err := fn(&slice[i])
+		if ok || err != nil {
+			slice[n] = slice[i]
+			n++
+		}
+		return err
+	})
+	if stopped < len(slice) {
+		// failed element was already kept at n-1
+		n += copy(slice[n:], slice[stopped+1:])
+	}
+	return truncate(slice, n), err
+}
+
+// TrySelect ... Select with condition function which can fail. return new slice, and slice is not modified.
+// failed elements are not selected. by default, stop at the first error and return selected elements before it.
+func TrySelect[T any](slice []T, fn TryCondFunc[T], opts ...Opt[TryOpt]) (result []T, err error) {
+
+	return TryConv(slice, func(t T) (T, bool, error) {
+		ok, err := fn(&t)
+		return t, !ok, err
+	}, opts...)
+}
+
+// TryConv ... Conv with conversion function which can fail.
+// failed elements are removed. by default, stop at the first error and return converted elements before it.
+func TryConv[S, D any](srcs []S, fn TryConvFunc[S, D], opts ...Opt[TryOpt]) (dsts []D, err error) {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	dsts = []D{}
+	_, err = tryEach(len(srcs), param.Param.isCollect, func(i int) error {
+		d, removed, err := fn(srcs[i])
+		if err == nil && !removed {
+			dsts = append(dsts, d)
+		}
+		return err
+	})
+	return
+}
+
+// TryInject ... Inject with inject function which can fail. result of failed element is ignored.
+// by default, stop at the first error and return the result before it.
+func TryInject[T, V any](s []T, injectFn TryInjectFn[T, V], opts ...Opt[TryOpt]) (v V, err error) {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	_, err = tryEach(len(s), param.Param.isCollect, func(i int) error {
+		nv, err := injectFn(v, s[i])
+		if err == nil {
+			v = nv
+		}
+		return err
+	})
+	return
+}
@@ -0,0 +1,107 @@
+package loncha
+
+// SetOpt ... functional option for Union()/SymmetricDifference()
+type SetOpt struct {
+	isBag bool
+}
+
+func (sopt *SetOpt) bag(v bool) (prev bool) {
+	prev = sopt.isBag
+	sopt.isBag = v
+	return prev
+}
+
+// Union ... union between 2 slice. order of first appearance is kept.
+// with Bag[SetOpt](true), each element is kept max(count in slice1, count in slice2) times.
+func Union[T comparable](slice1, slice2 []T, opts ...Opt[SetOpt]) (result []T) {
+
+	param, fn := MergeOpts(opts...)
+	defer fn(param)
+
+	result = make([]T, 0, len(slice1)+len(slice2))
+
+	if param.Param.isBag {
+		cnts := mapOfCounts(slice1)
+		result = append(result, slice1...)
+		for _, v := range slice2 {
+			if cnts[v] > 0 {
+				cnts[v]--
+				continue
+			}
+			result = append(result, v)
+		}
+		return
+	}
+
+	already := map[T]bool{}
+	for _, slice := range [][]T{slice1, slice2} {
+		for _, v := range slice {
+			if !already[v] {
+				result = append(result, v)
+				already[v] = true
+			}
+		}
+	}
+	return
+}
+
+// SymmetricDifference ... elements which exist only in one of 2 slice. elements of slice1 comes first.
+// with Bag[SetOpt](true), each element is kept |count in slice1 - count in slice2| times.
+func SymmetricDifference[T comparable](slice1, slice2 []T, opts ...Opt[SetOpt]) (result []T) {
+
+	param, fn := MergeOpts(opts...)
+	defer fn(param)
+
+	if param.Param.isBag {
+		result = subBag(slice1, slice2, &ctxOpt{})
+		return append(result, subBag(slice2, slice1, &ctxOpt{})...)
+	}
+
+	exists1, exists2 := mapOfExists(slice1), mapOfExists(slice2)
+	already := map[T]bool{}
+	result = make([]T, 0)
+
+	for _, pair := range []struct {
+		slice  []T
+		exists map[T]bool
+	}{{slice1, exists2}, {slice2, exists1}} {
+		for _, v := range pair.slice {
+			if !pair.exists[v] && !already[v] {
+				result = append(result, v)
+				already[v] = true
+			}
+		}
+	}
+	return
+}
+
+// UnionBy ... union between two slices by key of keyFn. T doesn't need to be comparable.
+// all elements of left are kept in order, and elements of right which key is not in left follow it.
+// use RightWin[ByOpt](true) to replace element of left by element of right with same key.
+func UnionBy[T any, K comparable](left, right []T, keyFn func(*T) K, opts ...Opt[ByOpt]) (result []T) {
+
+	param, fn := MergeOpts(opts...)
+	defer fn(param)
+
+	exists := mapOfIndexBy(right, keyFn)
+	already := make(map[K]bool, len(left))
+	result = make([]T, 0, len(left)+len(right))
+
+	for i := range left {
+		key := keyFn(&left[i])
+		already[key] = true
+		if j, found := exists[key]; found && param.Param.isRightWin {
+			result = append(result, right[j])
+			continue
+		}
+		result = append(result, left[i])
+	}
+	for i := range right {
+		key := keyFn(&right[i])
+		if !already[key] {
+			result = append(result, right[i])
+			already[key] = true
+		}
+	}
+	return
+}
@@ -69,7 +69,12 @@ func Uniq2(slice interface{}, fn CompareFunc) error {
 }
 
 // UniqWithSort is deduplicating using fn, sorting before dedup. if slice is not pointer of slice or empty, return error
-func UniqWithSort(slice interface{}, fn CompareFunc) (int, error) {
+// with WithContext[CtxOpt](), stop deduplicating when context is done and return the number of unique elements
+// found before it with *PartialError. sorting itself is not canceled.
+func UniqWithSort(slice interface{}, fn CompareFunc, opts ...Opt[CtxOpt]) (int, error) {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
 
 	pRv, err := slice2Reflect(slice)
 	if err != nil {
@@ -77,6 +82,10 @@ func UniqWithSort(slice interface{}, fn CompareFunc) (int, error) {
 	}
 	n := pRv.Elem().Len()
 
+	if err := param.Param.canceled(0); err != nil {
+		return 0, err
+	}
+
 	if !sort.SliceIsSorted(pRv.Elem().Interface(), fn) {
 		sort.Slice(pRv.Elem().Interface(), fn)
 	}
@@ -84,6 +93,11 @@ func UniqWithSort(slice interface{}, fn CompareFunc) (int, error) {
 
 	a, b := 0, 1
 	for b < n {
+		if param.Param.ctx != nil {
+			if err := param.Param.canceled(b); err != nil {
+				return a + 1, err
+			}
+		}
 		if fn(a, b) {
 			a++
 			if a != b {
@@ -95,3 +109,100 @@ func UniqWithSort(slice interface{}, fn CompareFunc) (int, error) {
 	return a + 1, nil
 
 }
+
+// UniqOpt ... functional option for UniqBy()/UniqInPlace()/UniqSorted()
+type UniqOpt struct {
+	isKeepLast bool
+}
+
+func (uopt *UniqOpt) keepLast(v bool) (prev bool) {
+	prev = uopt.isKeepLast
+	uopt.isKeepLast = v
+	return prev
+}
+
+type keepLastSetter[T any] interface {
+	keepLast(bool) bool
+	*T
+}
+
+// KeepLast ... keep last element of same key instead of first.
+func KeepLast[T any, PT keepLastSetter[T]](v bool) Opt[T] {
+	return func(p *opParam[T]) Opt[T] {
+		prev := PT(&p.Param).keepLast(v)
+		return KeepLast[T, PT](prev)
+	}
+}
+
+// truncate ... clear s[n:] so that dropped elements can be garbage collected, and return s[:n].
+func truncate[T any](s []T, n int) []T {
+	var zero T
+	for i := n; i < len(s); i++ {
+		s[i] = zero
+	}
+	return s[:n]
+}
+
+// UniqBy ... return new slice deduplicated by key of keyFn. order of kept elements is stable.
+// first element of same key is kept by default.
+func UniqBy[T any, K comparable](s []T, keyFn func(*T) K, opts ...Opt[UniqOpt]) []T {
+
+	dup := make([]T, len(s))
+	copy(dup, s)
+	return UniqInPlace(dup, keyFn, opts...)
+}
+
+// UniqInPlace ... same as UniqBy, but deduplicate s in place and return truncated s.
+func UniqInPlace[T any, K comparable](s []T, keyFn func(*T) K, opts ...Opt[UniqOpt]) []T {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	n := 0
+	if param.Param.isKeepLast {
+		lasts := make(map[K]int, len(s))
+		for i := range s {
+			lasts[keyFn(&s[i])] = i
+		}
+		for i := range s {
+			if lasts[keyFn(&s[i])] == i {
+				s[n] = s[i]
+				n++
+			}
+		}
+		return truncate(s, n)
+	}
+
+	exists := make(map[K]struct{}, len(s))
+	for i := range s {
+		key := keyFn(&s[i])
+		if _, found := exists[key]; found {
+			continue
+		}
+		exists[key] = struct{}{}
+		s[n] = s[i]
+		n++
+	}
+	return truncate(s, n)
+}
+
+// UniqSorted ... deduplicate sorted s in place by one linear pass without hashing, and return truncated s.
+func UniqSorted[T any, V Ordered](s []T, IdentFn IdentFunc[T, V], opts ...Opt[UniqOpt]) []T {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	n := 0
+	for i := range s {
+		if param.Param.isKeepLast {
+			if i+1 < len(s) && IdentFn(s, i+1) == IdentFn(s, i) {
+				continue
+			}
+		} else if n > 0 && IdentFn(s, n-1) == IdentFn(s, i) {
+			continue
+		}
+		s[n] = s[i]
+		n++
+	}
+	return truncate(s, n)
+}
@@ -0,0 +1,188 @@
+package loncha
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+)
+
+// WeightFunc ... return weight of element for WeightedChoice()/WeightedSample()
+type WeightFunc[T any, W Number] func(t *T) W
+
+// weights ... return weights as float64 and sum of them. return ERR_INVALID_WEIGHT if weights are invalid.
+func weights[T any, W Number](slice []T, weightFn WeightFunc[T, W]) (ws []float64, sum float64, err error) {
+
+	ws = make([]float64, len(slice))
+	for i := range slice {
+		w := float64(weightFn(&slice[i]))
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, 0, ERR_INVALID_WEIGHT
+		}
+		ws[i] = w
+		sum += w
+	}
+	if sum <= 0 {
+		return nil, 0, ERR_INVALID_WEIGHT
+	}
+	return
+}
+
+// WeightedChooser ... alias table for repeated weighted random selection.
+// Choice() is O(1) after O(n) preprocessing. WeightedChooser is not goroutine safe.
+type WeightedChooser[T any] struct {
+	slice []T
+	prob  []float64
+	alias []int
+	rand  *rand.Rand
+}
+
+// NewWeightedChooser ... build alias table of slice with weightFn by Vose's alias method.
+func NewWeightedChooser[T any, W Number](slice []T, weightFn WeightFunc[T, W], opts ...Opt[RandOpt]) (*WeightedChooser[T], error) {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	ws, sum, err := weights(slice, weightFn)
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(ws)
+	c := &WeightedChooser[T]{
+		slice: slice,
+		prob:  make([]float64, n),
+		alias: make([]int, n),
+		rand:  param.Param.rand(),
+	}
+
+	small := make([]int, 0, n)
+	large := make([]int, 0, n)
+	for i, w := range ws {
+		ws[i] = w * float64(n) / sum
+		if ws[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small, large = small[:len(small)-1], large[:len(large)-1]
+
+		c.prob[s], c.alias[s] = ws[s], l
+		ws[l] = ws[l] + ws[s] - 1
+		if ws[l] < 1 {
+			small = append(small, l)
+		} else {
+			large = append(large, l)
+		}
+	}
+	// remains are 1 except float error
+	for _, i := range append(small, large...) {
+		c.prob[i], c.alias[i] = 1, i
+	}
+	return c, nil
+}
+
+// ChoiceIndex ... return index of randomly chosen element.
+func (c *WeightedChooser[T]) ChoiceIndex() int {
+
+	i := c.rand.Intn(len(c.prob))
+	if c.rand.Float64() < c.prob[i] {
+		return i
+	}
+	return c.alias[i]
+}
+
+// Choice ... return randomly chosen element.
+func (c *WeightedChooser[T]) Choice() T {
+	return c.slice[c.ChoiceIndex()]
+}
+
+// WeightedChoice ... return one element chosen with probability proportional to weightFn.
+// use NewWeightedChooser() for repeated draws.
+func WeightedChoice[T any, W Number](slice []T, weightFn WeightFunc[T, W], opts ...Opt[RandOpt]) (t T, err error) {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	ws, sum, err := weights(slice, weightFn)
+	if err != nil {
+		return t, err
+	}
+
+	r := param.Param.rand().Float64() * sum
+	last := 0
+	for i, w := range ws {
+		if w == 0 {
+			continue
+		}
+		if r < w {
+			return slice[i], nil
+		}
+		r -= w
+		last = i
+	}
+	return slice[last], nil
+}
+
+type weightedKey struct {
+	idx int
+	key float64
+}
+
+// weightedKeys ... min heap of weightedKey
+type weightedKeys []weightedKey
+
+func (h weightedKeys) Len() int            { return len(h) }
+func (h weightedKeys) Less(i, j int) bool  { return h[i].key < h[j].key }
+func (h weightedKeys) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *weightedKeys) Push(x interface{}) { *h = append(*h, x.(weightedKey)) }
+func (h *weightedKeys) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// WeightedSample ... return k elements chosen without replacement with probability proportional to weightFn
+// by Efraimidis–Spirakis algorithm. elements of zero weight are never chosen,
+// so result may be shorter than k.
+func WeightedSample[T any, W Number](slice []T, k int, weightFn WeightFunc[T, W], opts ...Opt[RandOpt]) (result []T, err error) {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	ws, _, err := weights(slice, weightFn)
+	if err != nil {
+		return nil, err
+	}
+	if k <= 0 {
+		return []T{}, nil
+	}
+	randMt := param.Param.rand()
+
+	// key = u^(1/w) is compared as log(u)/w for precision.
+	h := make(weightedKeys, 0, k)
+	for i, w := range ws {
+		if w == 0 {
+			continue
+		}
+		key := math.Log(1-randMt.Float64()) / w
+		if len(h) < k {
+			heap.Push(&h, weightedKey{idx: i, key: key})
+			continue
+		}
+		if key > h[0].key {
+			h[0] = weightedKey{idx: i, key: key}
+			heap.Fix(&h, 0)
+		}
+	}
+
+	result = make([]T, len(h))
+	for i := len(h) - 1; i >= 0; i-- {
+		result[i] = slice[heap.Pop(&h).(weightedKey).idx]
+	}
+	return
+}
@@ -0,0 +1,110 @@
+package loncha
+
+// Pair ... tuple of 2 values generated by Zip()
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Triple ... tuple of 3 values generated by Zip3()
+type Triple[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// ZipOpt ... functional option for Zip()/Zip3()
+type ZipOpt struct {
+	isStrict bool
+}
+
+func (zopt *ZipOpt) strict(v bool) (prev bool) {
+	prev = zopt.isStrict
+	zopt.isStrict = v
+	return prev
+}
+
+type strictSetter[T any] interface {
+	strict(bool) bool
+	*T
+}
+
+// Strict ... return ERR_LENGTH_MISMATCH instead of truncating to the shortest slice.
+func Strict[T any, PT strictSetter[T]](v bool) Opt[T] {
+	return func(p *opParam[T]) Opt[T] {
+		prev := PT(&p.Param).strict(v)
+		return Strict[T, PT](prev)
+	}
+}
+
+// Zip ... return pairs of elements in same index. result is truncated to the shorter slice.
+// with Strict[ZipOpt](true), return ERR_LENGTH_MISMATCH if length of slices are different.
+func Zip[A, B any](as []A, bs []B, opts ...Opt[ZipOpt]) (pairs []Pair[A, B], err error) {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	if param.Param.isStrict && len(as) != len(bs) {
+		return nil, ERR_LENGTH_MISMATCH
+	}
+
+	pairs = make([]Pair[A, B], min(len(as), len(bs)))
+	for i := range pairs {
+		pairs[i] = Pair[A, B]{as[i], bs[i]}
+	}
+	return pairs, nil
+}
+
+// Zip3 ... 3 slices variant of Zip()
+func Zip3[A, B, C any](as []A, bs []B, cs []C, opts ...Opt[ZipOpt]) (triples []Triple[A, B, C], err error) {
+
+	param, prev := MergeOpts(opts...)
+	defer prev(param)
+
+	if param.Param.isStrict && (len(as) != len(bs) || len(as) != len(cs)) {
+		return nil, ERR_LENGTH_MISMATCH
+	}
+
+	triples = make([]Triple[A, B, C], min(len(as), min(len(bs), len(cs))))
+	for i := range triples {
+		triples[i] = Triple[A, B, C]{as[i], bs[i], cs[i]}
+	}
+	return triples, nil
+}
+
+// ZipLongest ... return pairs of elements in same index until the longer slice.
+// missing values are filled with Default() of fillA/fillB, or zero value if nil.
+func ZipLongest[A, B any](as []A, bs []B, fillA OptCurry[A], fillB OptCurry[B]) (pairs []Pair[A, B]) {
+
+	var defA A
+	var defB B
+	if fillA != nil {
+		defA = NewOpt(fillA).Default
+	}
+	if fillB != nil {
+		defB = NewOpt(fillB).Default
+	}
+
+	pairs = make([]Pair[A, B], max(len(as), len(bs)))
+	for i := range pairs {
+		pairs[i] = Pair[A, B]{defA, defB}
+		if i < len(as) {
+			pairs[i].First = as[i]
+		}
+		if i < len(bs) {
+			pairs[i].Second = bs[i]
+		}
+	}
+	return
+}
+
+// Unzip ... split pairs into 2 slices.
+func Unzip[A, B any](pairs []Pair[A, B]) (as []A, bs []B) {
+
+	as = make([]A, len(pairs))
+	bs = make([]B, len(pairs))
+	for i, p := range pairs {
+		as[i], bs[i] = p.First, p.Second
+	}
+	return
+}